@@ -147,6 +147,8 @@ func main() {
 		EnableBashCompletion: true,
 		Commands: []*cli.Command{
 			StartCommand,
+			MigrateCommand,
+			ConfigCommand,
 		},
 	}
 
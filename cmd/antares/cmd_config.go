@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/start"
+)
+
+// ConfigCommand contains the config sub-command configuration.
+var ConfigCommand = &cli.Command{
+	Name:  "config",
+	Usage: "Inspects the configuration.",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "validate",
+			Usage:  "Checks the configuration and lists all problems.",
+			Action: ConfigValidateAction,
+		},
+	},
+}
+
+// ConfigValidateAction checks the configuration and exits with a non-zero exit code if it has problems.
+// It doesn't write the configuration file and fails if it doesn't exist.
+func ConfigValidateAction(c *cli.Context) error {
+	conf, err := config.Load(c)
+	if err != nil {
+		return errors.Wrap(err, "load configuration")
+	}
+
+	problems := start.ValidateConfig(conf)
+	if len(problems) == 0 {
+		fmt.Println("Configuration is valid:", conf.Path)
+		return nil
+	}
+
+	fmt.Println("Configuration has problems:", conf.Path)
+	for _, problem := range problems {
+		fmt.Println("  -", problem)
+	}
+
+	return fmt.Errorf("found %d problems in configuration", len(problems))
+}
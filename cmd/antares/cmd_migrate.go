@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/db"
+)
+
+// MigrateCommand contains the migrate sub-command configuration.
+var MigrateCommand = &cli.Command{
+	Name:  "migrate",
+	Usage: "Applies or rolls back the database migrations that are embedded in the binary.",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "up",
+			Usage:  "Applies all pending migrations.",
+			Action: MigrateUpAction,
+		},
+		{
+			Name:   "down",
+			Usage:  "Rolls back the latest migrations.",
+			Action: MigrateDownAction,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:        "steps",
+					Usage:       "The number of migrations to roll back",
+					DefaultText: "1",
+					Value:       1,
+				},
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Roll back all migrations (deletes all data)",
+				},
+			},
+		},
+		{
+			Name:   "status",
+			Usage:  "Prints the current schema version and pending migrations.",
+			Action: MigrateStatusAction,
+		},
+	},
+}
+
+// MigrateUpAction applies all pending migrations.
+func MigrateUpAction(c *cli.Context) error {
+	dbc, err := initMigrateClient(c)
+	if err != nil {
+		return err
+	}
+	defer dbc.Close()
+
+	log.Infoln("Applying database migrations...")
+	if err = dbc.MigrateUp(c.Context); err != nil {
+		return err
+	}
+	log.Infoln("Applied database migrations!")
+
+	return nil
+}
+
+// MigrateDownAction rolls back the given number of migrations.
+func MigrateDownAction(c *cli.Context) error {
+	dbc, err := initMigrateClient(c)
+	if err != nil {
+		return err
+	}
+	defer dbc.Close()
+
+	steps := c.Int("steps")
+	if c.Bool("all") {
+		steps = 0
+	} else if steps <= 0 {
+		return fmt.Errorf("steps must be positive, use --all to roll back all migrations")
+	}
+
+	log.WithField("steps", steps).Infoln("Rolling back database migrations...")
+	if err = dbc.MigrateDown(c.Context, steps); err != nil {
+		return err
+	}
+	log.Infoln("Rolled back database migrations!")
+
+	return nil
+}
+
+// MigrateStatusAction prints the current schema version and all pending migrations.
+func MigrateStatusAction(c *cli.Context) error {
+	dbc, err := initMigrateClient(c)
+	if err != nil {
+		return err
+	}
+	defer dbc.Close()
+
+	status, err := dbc.MigrationStatus(c.Context)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Version:", status.Version)
+	fmt.Println("Dirty:  ", status.Dirty)
+	fmt.Println("Pending:", status.Pending())
+
+	return nil
+}
+
+func initMigrateClient(c *cli.Context) (*db.Client, error) {
+	conf, err := config.Init(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "init configuration")
+	}
+
+	if conf.Database.DryRun {
+		return nil, fmt.Errorf("can't migrate the database in dry-run mode")
+	}
+
+	return db.InitClient(conf)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/dennis-tra/antares/pkg/db"
 	"github.com/dennis-tra/antares/pkg/maxmind"
 	"github.com/dennis-tra/antares/pkg/metrics"
+	"github.com/dennis-tra/antares/pkg/sink"
 	"github.com/dennis-tra/antares/pkg/start"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -16,6 +17,13 @@ var StartCommand = &cli.Command{
 	Name:   "start",
 	Usage:  "Starts to provide content to the network and request it through gateways and pinning services.",
 	Action: StartAction,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "auto-migrate",
+			Usage:   "Apply pending database migrations before starting",
+			EnvVars: []string{"ANTARES_DATABASE_AUTOMIGRATE"},
+		},
+	},
 }
 
 // StartAction is the command line action to start the libp2p host that provides content to the network
@@ -36,7 +44,25 @@ func StartAction(c *cli.Context) error {
 		if dbc, err = db.InitClient(conf); err != nil {
 			return err
 		}
+
+		if conf.Database.AutoMigrate {
+			log.Infoln("Applying database migrations...")
+			if err = dbc.MigrateUp(c.Context); err != nil {
+				return errors.Wrap(err, "migrate database")
+			}
+		}
+	}
+
+	// Initialize the sinks that receive the probe results
+	snk, err := sink.New(conf, dbc)
+	if err != nil {
+		return errors.Wrap(err, "init sinks")
 	}
+	defer func() {
+		if err := snk.Close(); err != nil {
+			log.WithError(err).Warnln("Error closing sinks")
+		}
+	}()
 
 	// Initialize new maxmind client to interact with the country database.
 	mmc, err := maxmind.NewClient()
@@ -53,7 +79,7 @@ func StartAction(c *cli.Context) error {
 	}
 
 	// Initialize scheduler that handles probing the targets
-	s, err := start.NewScheduler(c.Context, conf, dbc, mmc)
+	s, err := start.NewScheduler(c.Context, conf, snk, mmc)
 	if err != nil {
 		return errors.Wrap(err, "creating new scheduler")
 	}
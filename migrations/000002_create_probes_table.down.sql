@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS probes;
+DROP TYPE IF EXISTS probe_outcome;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- The different outcomes a probe can have
+CREATE TYPE probe_outcome AS ENUM (
+    'success', -- at least one peer requested the content through the target
+    'timeout', -- no peer requested the content within the timeout of the target
+    'error',   -- the operation of the target failed permanently
+    'canceled' -- Antares was stopped while the probe was running
+    );
+
+-- The `probes` table keeps track of all probe runs. A probe run is the publication of a single CID
+-- and the subsequent request of that CID through a target.
+CREATE TABLE probes
+(
+    -- The unique identifier in the scope of this database
+    id          BIGINT GENERATED ALWAYS AS IDENTITY,
+    -- Type of the target, e.g., gateway or pinning service
+    target_type TEXT        NOT NULL,
+    -- Name of the target, e.g., ipfs.io
+    target_name TEXT        NOT NULL,
+    -- The CID that was published and requested through the target
+    cid         TEXT        NOT NULL,
+    -- The outcome of the probe (NULL while the probe is running)
+    outcome     probe_outcome,
+    -- The error message if the operation of the target failed
+    error       TEXT,
+    -- The timestamp at which the probe started
+    started_at  TIMESTAMPTZ NOT NULL,
+    -- The timestamp at which the probe ended (NULL while the probe is running)
+    ended_at    TIMESTAMPTZ,
+    -- The timestamp at which this row was inserted into the database
+    created_at  TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_probes_target_name_started_at ON probes (target_name, started_at);
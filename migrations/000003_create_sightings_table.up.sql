@@ -0,0 +1,38 @@
+-- The `sightings` table keeps track of every peer that was observed during a probe. In contrast to the
+-- `peers` table, which only holds the latest state of a peer, rows in this table keep the state of the peer
+-- as it was seen. Only the outcome of the peer's want is updated once the probe has ended.
+CREATE TABLE sightings
+(
+    -- The unique identifier in the scope of this database
+    id              BIGINT GENERATED ALWAYS AS IDENTITY,
+    -- The probe during which the peer was observed
+    probe_id        BIGINT      NOT NULL,
+    -- The peer that was observed
+    peer_id         BIGINT      NOT NULL,
+    -- The agent version of the peer at the time of the sighting, e.g., kubo/0.15.0
+    agent_version   TEXT,
+    -- An array of supported protocols at the time of the sighting, e.g., bitswap/1.0.0
+    protocols       TEXT[],
+    -- An array of multi addresses at which that peer was reachable at the time of the sighting
+    multi_addresses TEXT[]      NOT NULL,
+    -- An array of extracted ip_addresses from the multi_address array
+    ip_addresses    TEXT[]      NOT NULL,
+    -- An array of countries that the IP addresses could be associated with
+    countries       TEXT[]      NOT NULL,
+    -- An array of continents that the IP addresses could be associated with
+    continents      TEXT[]      NOT NULL,
+    -- An array of autonomous system numbers that the IP addresses could be associated with
+    asns            INT[]       NOT NULL,
+    -- The timestamp at which the peer was observed
+    seen_at         TIMESTAMPTZ NOT NULL,
+    -- The timestamp at which this row was inserted into the database
+    created_at      TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT fk_sightings_probe_id FOREIGN KEY (probe_id) REFERENCES probes (id) ON DELETE CASCADE,
+    CONSTRAINT fk_sightings_peer_id FOREIGN KEY (peer_id) REFERENCES peers (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_sightings_probe_id ON sightings (probe_id);
+CREATE INDEX idx_sightings_peer_id_seen_at ON sightings (peer_id, seen_at);
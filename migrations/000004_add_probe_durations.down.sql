@@ -0,0 +1,4 @@
+ALTER TABLE probes
+    DROP COLUMN IF EXISTS provide_duration,
+    DROP COLUMN IF EXISTS operation_duration,
+    DROP COLUMN IF EXISTS first_want_latency;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE probes
+    -- The time it took to provide the CID in the DHT
+    ADD COLUMN provide_duration   INTERVAL,
+    -- The time it took for the operation of the target to complete (NULL if it did not complete)
+    ADD COLUMN operation_duration INTERVAL,
+    -- The time from the start of the operation until the first Bitswap want for the CID arrived
+    ADD COLUMN first_want_latency INTERVAL;
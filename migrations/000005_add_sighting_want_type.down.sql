@@ -0,0 +1,4 @@
+ALTER TABLE sightings
+    DROP COLUMN IF EXISTS want_type;
+
+DROP TYPE IF EXISTS want_type;
\ No newline at end of file
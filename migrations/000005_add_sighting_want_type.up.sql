@@ -0,0 +1,9 @@
+-- The different types of Bitswap wants
+CREATE TYPE want_type AS ENUM (
+    'block', -- the peer asked for the block itself
+    'have'   -- the peer asked whether we have the block
+    );
+
+ALTER TABLE sightings
+    -- The type of the first Bitswap want of the peer (NULL if the peer was not seen through Bitswap)
+    ADD COLUMN want_type want_type;
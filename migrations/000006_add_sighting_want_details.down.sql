@@ -0,0 +1,5 @@
+ALTER TABLE sightings
+    DROP COLUMN IF EXISTS want_priority,
+    DROP COLUMN IF EXISTS send_dont_have,
+    DROP COLUMN IF EXISTS canceled_at,
+    DROP COLUMN IF EXISTS block_sent_at;
\ No newline at end of file
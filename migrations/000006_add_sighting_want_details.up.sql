@@ -0,0 +1,9 @@
+ALTER TABLE sightings
+    -- The priority of the first Bitswap want of the peer
+    ADD COLUMN want_priority  INT,
+    -- Whether the peer asked us to send a DONT_HAVE if we don't have the block
+    ADD COLUMN send_dont_have BOOLEAN,
+    -- The timestamp at which the peer canceled its want (NULL if it did not)
+    ADD COLUMN canceled_at    TIMESTAMPTZ,
+    -- The timestamp at which we sent the block to the peer (NULL if we did not)
+    ADD COLUMN block_sent_at  TIMESTAMPTZ;
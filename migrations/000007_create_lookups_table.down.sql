@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS lookups;
+DROP TYPE IF EXISTS lookup_type;
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- The different types of DHT requests that are recorded
+CREATE TYPE lookup_type AS ENUM (
+    'get_providers', -- the peer asked us for provider records of the CID
+    'find_node'      -- the peer asked us for the closest peers to the CID
+    );
+
+-- The `lookups` table keeps track of every peer that looked up a probe CID in the DHT through us.
+-- Peers that do this without a subsequent Bitswap want may be sniffing DHT traffic.
+CREATE TABLE lookups
+(
+    -- The unique identifier in the scope of this database
+    id              BIGINT GENERATED ALWAYS AS IDENTITY,
+    -- The probe whose CID was looked up
+    probe_id        BIGINT      NOT NULL,
+    -- The peerID of the requesting peer in form of QmFoo or 12D3Bar
+    multi_hash      TEXT        NOT NULL,
+    -- The agent version of the requesting peer at the time of the lookup, e.g., kubo/0.15.0
+    agent_version   TEXT,
+    -- An array of multi addresses of the requesting peer at the time of the lookup
+    multi_addresses TEXT[]      NOT NULL,
+    -- The type of the DHT request
+    type            lookup_type NOT NULL,
+    -- The timestamp at which the request was received
+    seen_at         TIMESTAMPTZ NOT NULL,
+    -- The timestamp at which this row was inserted into the database
+    created_at      TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT fk_lookups_probe_id FOREIGN KEY (probe_id) REFERENCES probes (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_lookups_probe_id ON lookups (probe_id);
@@ -0,0 +1,4 @@
+ALTER TABLE probes
+    DROP COLUMN IF EXISTS verification;
+
+DROP TYPE IF EXISTS probe_verification;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- The different results of verifying the content that a target served
+CREATE TYPE probe_verification AS ENUM (
+    'valid',     -- the content was signed by Antares and belongs to the probe
+    'corrupted', -- the content did not match the CID or carried an invalid signature
+    'stale'      -- the content was signed by Antares but belongs to an earlier probe
+    );
+
+ALTER TABLE probes
+    -- The result of verifying the retrieved content (NULL if the target does not retrieve content)
+    ADD COLUMN verification probe_verification;
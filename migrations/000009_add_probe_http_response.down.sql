@@ -0,0 +1,7 @@
+ALTER TABLE probes
+    DROP COLUMN IF EXISTS http_status_code,
+    DROP COLUMN IF EXISTS http_url,
+    DROP COLUMN IF EXISTS http_redirects,
+    DROP COLUMN IF EXISTS http_headers,
+    DROP COLUMN IF EXISTS http_tls_version,
+    DROP COLUMN IF EXISTS http_bytes;
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE probes
+    -- The status code of the last HTTP response of the target (NULL if the target was not requested via HTTP)
+    ADD COLUMN http_status_code INT,
+    -- The URL that served the last HTTP response after following all redirects
+    ADD COLUMN http_url         TEXT,
+    -- The URLs that redirected to the http_url in the order they were requested
+    ADD COLUMN http_redirects   TEXT[],
+    -- Headers of the last HTTP response that identify the CDN and caching layers of the target
+    ADD COLUMN http_headers     JSONB,
+    -- The TLS version of the connection that served the last HTTP response (NULL if it was not encrypted)
+    ADD COLUMN http_tls_version TEXT,
+    -- The number of bytes of the last HTTP response body
+    ADD COLUMN http_bytes       BIGINT;
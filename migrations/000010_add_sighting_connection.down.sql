@@ -0,0 +1,5 @@
+ALTER TABLE sightings
+    DROP COLUMN IF EXISTS transport,
+    DROP COLUMN IF EXISTS ip_family;
+
+DROP TYPE IF EXISTS ip_family;
\ No newline at end of file
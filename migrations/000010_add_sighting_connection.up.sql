@@ -0,0 +1,11 @@
+-- The IP families of connections
+CREATE TYPE ip_family AS ENUM (
+    'ipv4',
+    'ipv6'
+    );
+
+ALTER TABLE sightings
+    -- The transport of the connection on which the peer was seen, e.g., tcp, quic-v1 or webtransport (NULL if the peer was not connected)
+    ADD COLUMN transport TEXT,
+    -- The IP family of the connection on which the peer was seen (NULL if the peer was not connected)
+    ADD COLUMN ip_family ip_family;
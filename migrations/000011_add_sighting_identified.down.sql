@@ -0,0 +1,2 @@
+ALTER TABLE sightings
+    DROP COLUMN IF EXISTS identified;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE sightings
+    -- Whether the identify exchange with the peer succeeded before it was recorded. If not, its
+    -- agent version and protocols are missing rather than empty (NULL for older sightings)
+    ADD COLUMN identified BOOLEAN;
@@ -0,0 +1,9 @@
+ALTER TABLE sightings
+    DROP COLUMN IF EXISTS direction,
+    DROP COLUMN IF EXISTS security_protocol,
+    DROP COLUMN IF EXISTS muxer,
+    DROP COLUMN IF EXISTS conn_opened_at,
+    DROP COLUMN IF EXISTS relayed,
+    DROP COLUMN IF EXISTS ping_rtt;
+
+DROP TYPE IF EXISTS conn_direction;
\ No newline at end of file
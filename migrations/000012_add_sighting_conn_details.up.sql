@@ -0,0 +1,19 @@
+-- The directions of connections
+CREATE TYPE conn_direction AS ENUM (
+    'inbound',
+    'outbound'
+    );
+
+ALTER TABLE sightings
+    -- Whether the peer dialed us (inbound) or we dialed the peer (outbound) (NULL if the peer was not connected)
+    ADD COLUMN direction         conn_direction,
+    -- The security protocol of the connection, e.g., /tls/1.0.0 or /noise (NULL if the peer was not connected)
+    ADD COLUMN security_protocol TEXT,
+    -- The stream multiplexer of the connection, e.g., /yamux/1.0.0 (NULL if the peer was not connected or for QUIC)
+    ADD COLUMN muxer             TEXT,
+    -- The time at which the connection was opened (NULL if the peer was not connected)
+    ADD COLUMN conn_opened_at    TIMESTAMPTZ,
+    -- Whether the connection was relayed through another peer (NULL if the peer was not connected)
+    ADD COLUMN relayed           BOOLEAN,
+    -- The round trip time of a libp2p ping to the peer (NULL if the ping failed)
+    ADD COLUMN ping_rtt          INTERVAL;
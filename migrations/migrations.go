@@ -0,0 +1,15 @@
+// Package migrations embeds the SQL migration files, so that the antares binary
+// can migrate the database without the golang-migrate CLI.
+package migrations
+
+import "embed"
+
+// FS contains all up and down migration files of this directory.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// SQLiteFS contains the SQLite equivalents of the migration files in the sqlite directory.
+//
+//go:embed sqlite/*.sql
+var SQLiteFS embed.FS
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS peers;
\ No newline at end of file
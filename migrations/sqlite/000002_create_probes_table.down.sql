@@ -0,0 +1 @@
+DROP TABLE IF EXISTS probes;
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- The `probes` table keeps track of all probe runs. A probe run is the publication of a single CID
+-- and the subsequent request of that CID through a target.
+CREATE TABLE probes
+(
+    -- The unique identifier in the scope of this database
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Type of the target, e.g., gateway or pinning service
+    target_type TEXT NOT NULL,
+    -- Name of the target, e.g., ipfs.io
+    target_name TEXT NOT NULL,
+    -- The CID that was published and requested through the target
+    cid         TEXT NOT NULL,
+    -- The outcome of the probe (NULL while the probe is running)
+    outcome     TEXT CHECK (outcome IN ('success', 'timeout', 'error', 'canceled')),
+    -- The error message if the operation of the target failed
+    error       TEXT,
+    -- The timestamp at which the probe started
+    started_at  TEXT NOT NULL,
+    -- The timestamp at which the probe ended (NULL while the probe is running)
+    ended_at    TEXT,
+    -- The timestamp at which this row was inserted into the database
+    created_at  TEXT NOT NULL
+);
+
+CREATE INDEX idx_probes_target_name_started_at ON probes (target_name, started_at);
@@ -0,0 +1,3 @@
+ALTER TABLE probes DROP COLUMN provide_duration;
+ALTER TABLE probes DROP COLUMN operation_duration;
+ALTER TABLE probes DROP COLUMN first_want_latency;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- The time it took to provide the CID in the DHT in seconds
+ALTER TABLE probes ADD COLUMN provide_duration REAL;
+-- The time it took for the operation of the target to complete in seconds (NULL if it did not complete)
+ALTER TABLE probes ADD COLUMN operation_duration REAL;
+-- The time from the start of the operation until the first Bitswap want for the CID arrived in seconds
+ALTER TABLE probes ADD COLUMN first_want_latency REAL;
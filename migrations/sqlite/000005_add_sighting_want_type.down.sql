@@ -0,0 +1 @@
+ALTER TABLE sightings DROP COLUMN want_type;
\ No newline at end of file
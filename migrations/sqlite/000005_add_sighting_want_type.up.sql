@@ -0,0 +1,2 @@
+-- The type of the first Bitswap want of the peer (NULL if the peer was not seen through Bitswap)
+ALTER TABLE sightings ADD COLUMN want_type TEXT CHECK (want_type IN ('block', 'have'));
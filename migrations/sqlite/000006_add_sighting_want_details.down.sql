@@ -0,0 +1,4 @@
+ALTER TABLE sightings DROP COLUMN want_priority;
+ALTER TABLE sightings DROP COLUMN send_dont_have;
+ALTER TABLE sightings DROP COLUMN canceled_at;
+ALTER TABLE sightings DROP COLUMN block_sent_at;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- The priority of the first Bitswap want of the peer
+ALTER TABLE sightings ADD COLUMN want_priority INTEGER;
+-- Whether the peer asked us to send a DONT_HAVE if we don't have the block
+ALTER TABLE sightings ADD COLUMN send_dont_have BOOLEAN;
+-- The timestamp at which the peer canceled its want (NULL if it did not)
+ALTER TABLE sightings ADD COLUMN canceled_at TEXT;
+-- The timestamp at which we sent the block to the peer (NULL if we did not)
+ALTER TABLE sightings ADD COLUMN block_sent_at TEXT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lookups;
\ No newline at end of file
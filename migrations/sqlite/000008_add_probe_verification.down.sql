@@ -0,0 +1 @@
+ALTER TABLE probes DROP COLUMN verification;
\ No newline at end of file
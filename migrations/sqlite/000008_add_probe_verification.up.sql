@@ -0,0 +1,2 @@
+-- The result of verifying the retrieved content (NULL if the target does not retrieve content)
+ALTER TABLE probes ADD COLUMN verification TEXT CHECK (verification IN ('valid', 'corrupted', 'stale'));
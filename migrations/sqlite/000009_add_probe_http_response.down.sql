@@ -0,0 +1,6 @@
+ALTER TABLE probes DROP COLUMN http_status_code;
+ALTER TABLE probes DROP COLUMN http_url;
+ALTER TABLE probes DROP COLUMN http_redirects;
+ALTER TABLE probes DROP COLUMN http_headers;
+ALTER TABLE probes DROP COLUMN http_tls_version;
+ALTER TABLE probes DROP COLUMN http_bytes;
\ No newline at end of file
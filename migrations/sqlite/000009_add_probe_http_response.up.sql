@@ -0,0 +1,12 @@
+-- The status code of the last HTTP response of the target (NULL if the target was not requested via HTTP)
+ALTER TABLE probes ADD COLUMN http_status_code INTEGER;
+-- The URL that served the last HTTP response after following all redirects
+ALTER TABLE probes ADD COLUMN http_url TEXT;
+-- The URLs that redirected to the http_url in the order they were requested (JSON array)
+ALTER TABLE probes ADD COLUMN http_redirects TEXT;
+-- Headers of the last HTTP response that identify the CDN and caching layers of the target (JSON object)
+ALTER TABLE probes ADD COLUMN http_headers TEXT;
+-- The TLS version of the connection that served the last HTTP response (NULL if it was not encrypted)
+ALTER TABLE probes ADD COLUMN http_tls_version TEXT;
+-- The number of bytes of the last HTTP response body
+ALTER TABLE probes ADD COLUMN http_bytes INTEGER;
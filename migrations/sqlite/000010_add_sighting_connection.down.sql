@@ -0,0 +1,2 @@
+ALTER TABLE sightings DROP COLUMN transport;
+ALTER TABLE sightings DROP COLUMN ip_family;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- The transport of the connection on which the peer was seen, e.g., tcp, quic-v1 or webtransport (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN transport TEXT;
+-- The IP family of the connection on which the peer was seen (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN ip_family TEXT CHECK (ip_family IN ('ipv4', 'ipv6'));
@@ -0,0 +1 @@
+ALTER TABLE sightings DROP COLUMN identified;
\ No newline at end of file
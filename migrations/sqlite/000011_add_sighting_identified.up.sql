@@ -0,0 +1,3 @@
+-- Whether the identify exchange with the peer succeeded before it was recorded. If not, its
+-- agent version and protocols are missing rather than empty (NULL for older sightings)
+ALTER TABLE sightings ADD COLUMN identified BOOLEAN;
@@ -0,0 +1,6 @@
+ALTER TABLE sightings DROP COLUMN direction;
+ALTER TABLE sightings DROP COLUMN security_protocol;
+ALTER TABLE sightings DROP COLUMN muxer;
+ALTER TABLE sightings DROP COLUMN conn_opened_at;
+ALTER TABLE sightings DROP COLUMN relayed;
+ALTER TABLE sightings DROP COLUMN ping_rtt;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Whether the peer dialed us (inbound) or we dialed the peer (outbound) (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN direction TEXT CHECK (direction IN ('inbound', 'outbound'));
+-- The security protocol of the connection, e.g., /tls/1.0.0 or /noise (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN security_protocol TEXT;
+-- The stream multiplexer of the connection, e.g., /yamux/1.0.0 (NULL if the peer was not connected or for QUIC)
+ALTER TABLE sightings ADD COLUMN muxer TEXT;
+-- The time at which the connection was opened (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN conn_opened_at TEXT;
+-- Whether the connection was relayed through another peer (NULL if the peer was not connected)
+ALTER TABLE sightings ADD COLUMN relayed BOOLEAN;
+-- The round trip time of a libp2p ping to the peer in seconds (NULL if the ping failed)
+ALTER TABLE sightings ADD COLUMN ping_rtt REAL;
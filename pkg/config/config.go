@@ -4,11 +4,16 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/adrg/xdg"
+	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -36,50 +41,64 @@ var DefaultConfig = Config{
 		Port: 2004,
 	},
 	Database: struct {
-		DryRun   bool
-		Host     string
-		Port     int
-		Name     string
-		Password string
-		User     string
-		SSLMode  string
+		DryRun      bool
+		AutoMigrate bool
+		Host        string
+		Port        int
+		Name        string
+		Password    string
+		User        string
+		SSLMode     string
 	}{
-		DryRun:   false,
-		Host:     "0.0.0.0",
-		Port:     5432,
-		Name:     "antares",
-		Password: "password",
-		User:     "antares",
-		SSLMode:  "disable",
+		DryRun:      false,
+		AutoMigrate: false,
+		Host:        "0.0.0.0",
+		Port:        5432,
+		Name:        "antares",
+		Password:    "password",
+		User:        "antares",
+		SSLMode:     "disable",
+	},
+	HTTP: HTTP{
+		Timeout:             5 * time.Minute,
+		MaxIdleConnsPerHost: 4,
+	},
+	Network: Network{
+		MinBootstrapPeers: 1,
 	},
-	PrivKeyRaw:      nil,
-	PinningServices: []PinningService{},
-	Gateways:        []Gateway{},
-	UploadServices:  []UploadService{},
+	ConnectionManager: ConnectionManager{
+		LowWater:    600,
+		HighWater:   900,
+		GracePeriod: 20 * time.Second,
+	},
+	MaxConcurrentProbes: 100,
+	PrivKeyRaw:          nil,
+	PinningServices:     []PinningService{},
+	Gateways:            []Gateway{},
+	UploadServices:      []UploadService{},
+	Sinks:               []Sink{},
 }
 
 // Config contains general user configuration.
 type Config struct {
 	// The version string of nebula
-	Version string `json:"-"`
+	Version string `json:"-" mapstructure:"-"`
 
 	// The path where the configuration file is located.
-	Path string `json:"-"`
+	Path string `json:"-" mapstructure:"-"`
 
 	// Whether the configuration file existed when nebula was started
-	Existed bool `json:"-"`
+	Existed bool `json:"-" mapstructure:"-"`
 
-	// Determines the IPv4 network interface Antares should bind to
+	// Determines the network interface Antares should bind to if no ListenAddrs are configured.
 	Host string
 
-	// Determines the port at which Antares will be reachable.
+	// Determines the port at which Antares will be reachable if no ListenAddrs are configured.
 	Port int
 
-	// TODO
-	ListenAddrTCP ma.Multiaddr `json:"-"`
-
-	// TODO
-	ListenAddrQUIC ma.Multiaddr `json:"-"`
+	// The multi addresses Antares listens on, e.g., /ip6/::/udp/2002/quic-v1. Replaces the
+	// default addresses that are derived from Host and Port.
+	ListenAddrs []string `json:",omitempty"`
 
 	// Prometheus contains the prometheus configuration
 	Prometheus struct {
@@ -95,6 +114,9 @@ type Config struct {
 		// Whether a database connection should be established or not
 		DryRun bool
 
+		// Whether pending migrations should be applied when Antares starts
+		AutoMigrate bool
+
 		// Determines the host address of the database.
 		Host string
 
@@ -104,7 +126,8 @@ type Config struct {
 		// Determines the name of the database that should be used.
 		Name string
 
-		// Determines the password with which we access the database.
+		// Determines the password with which we access the database. Can reference a
+		// secret with env:NAME or file:/path instead of containing it.
 		Password string
 
 		// Determines the username with which we access the database.
@@ -114,11 +137,26 @@ type Config struct {
 		SSLMode string
 	}
 
+	// HTTP configures the HTTP client that all targets share unless they override it.
+	HTTP HTTP
+
+	// Network configures the libp2p network that Antares joins.
+	Network Network
+
+	// ResourceManager configures the resources that the libp2p host may use.
+	ResourceManager ResourceManager
+
+	// ConnectionManager configures how many connections the libp2p host keeps open.
+	ConnectionManager ConnectionManager
+
+	// Determines how many probes may be in flight at the same time across all targets. Zero disables the limit.
+	MaxConcurrentProbes int
+
 	// TODO
 	PrivKeyRaw []byte
 
 	// TODO
-	PrivKey crypto.PrivKey `json:"-"`
+	PrivKey crypto.PrivKey `json:"-" mapstructure:"-"`
 
 	// TODO
 	PinningServices []PinningService
@@ -127,21 +165,264 @@ type Config struct {
 	Gateways []Gateway
 
 	UploadServices []UploadService
+
+	// Sinks configures where the probe results are written to. If empty, results are written to
+	// the database, or only logged in dry-run mode.
+	Sinks []Sink
 }
 
 type PinningService struct {
-	Target        string
+	// The name of the constructor that's used to create the target, e.g., pinata or psa.
+	Target string
+
+	// The name under which the target's results are recorded. Falls back to the Target
+	// for services that don't support custom names.
+	Name string `json:",omitempty"`
+
+	// The base URL of the service's API for generic targets, e.g., https://api.example.com/psa.
+	Endpoint string `json:",omitempty"`
+
+	// The credentials for the service. Their format depends on the Target. Can reference a
+	// secret with env:NAME or file:/path instead of containing it.
 	Authorization string
+
+	// The authorization scheme for generic targets that support multiple, e.g., basic, bearer or none.
+	AuthScheme string `json:",omitempty"`
+
+	// Additional options that are passed along with each pin request, e.g., recursive=false.
+	PinOptions map[string]string `json:",omitempty"`
+
+	// Overrides of the global HTTP client configuration for this target.
+	HTTP HTTP
+
+	Probe `mapstructure:",squash"`
+}
+
+// Sink configures a destination for the probe results.
+type Sink struct {
+	// The type of the sink: postgres, sqlite, jsonl, csv or log.
+	Type string
+
+	// The file (sqlite, jsonl) or directory (csv) to write to. A jsonl sink writes to stdout if empty.
+	Path string `json:",omitempty"`
 }
 
 type UploadService struct {
-	Target        string
+	Target string
+
+	// The credentials for the service. Can reference a secret with env:NAME or file:/path instead of containing it.
 	Authorization string
+
+	// Overrides of the global HTTP client configuration for this target.
+	HTTP HTTP
+
+	Probe `mapstructure:",squash"`
 }
 
 type Gateway struct {
 	Name string
 	URL  string
+
+	// The way the content is requested from the gateway: path (default), raw, car, accept-raw or accept-car.
+	Mode string `json:",omitempty"`
+
+	// Overrides of the global HTTP client configuration for this target.
+	HTTP HTTP
+
+	Probe `mapstructure:",squash"`
+}
+
+// Network contains the configuration of the libp2p network that Antares joins. The defaults join the IPFS network.
+type Network struct {
+	// The multi addresses of the peers Antares connects to on startup including their peer IDs,
+	// e.g., /ip4/127.0.0.1/tcp/4001/p2p/12D3KooW.... Defaults to the IPFS bootstrap peers.
+	BootstrapPeers []string `json:",omitempty"`
+
+	// The minimum number of bootstrap peers Antares must connect to on startup.
+	MinBootstrapPeers int
+
+	// The path to the swarm key file of a private network, e.g., ~/.ipfs/swarm.key. Only TCP and
+	// WebSocket transports are supported in private networks.
+	SwarmKeyPath string `json:",omitempty"`
+
+	// The prefix of the DHT protocol, e.g., /myapp for /myapp/kad/1.0.0. Defaults to /ipfs.
+	ProtocolPrefix string `json:",omitempty"`
+}
+
+// BootstrapAddrInfos returns the peers that Antares connects to on startup.
+func (n Network) BootstrapAddrInfos() ([]peer.AddrInfo, error) {
+	if len(n.BootstrapPeers) == 0 {
+		return kaddht.GetDefaultBootstrapPeerAddrInfos(), nil
+	}
+
+	maddrs := make([]ma.Multiaddr, len(n.BootstrapPeers))
+	for i, addr := range n.BootstrapPeers {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse bootstrap peer %s", addr)
+		}
+		maddrs[i] = maddr
+	}
+
+	return peer.AddrInfosFromP2pAddrs(maddrs...)
+}
+
+// SwarmKey reads the pre-shared key of the private network from the swarm key file.
+// It returns nil if Antares should join a public network.
+func (n Network) SwarmKey() (pnet.PSK, error) {
+	if n.SwarmKeyPath == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(n.SwarmKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "open swarm key")
+	}
+	defer f.Close()
+
+	psk, err := pnet.DecodeV1PSK(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode swarm key")
+	}
+
+	return psk, nil
+}
+
+// ResourceManager contains the configuration of the libp2p resource manager. Its limits of connections, streams,
+// memory and file descriptors are scaled to the resources that are available to libp2p.
+type ResourceManager struct {
+	// The memory in bytes that libp2p may use. Defaults to an eighth of the system memory.
+	MaxMemory int64 `json:",omitempty"`
+
+	// The number of file descriptors that libp2p may use. Defaults to half of the limit of the process, but at least 4096.
+	MaxFileDescriptors int `json:",omitempty"`
+}
+
+// ConnectionManager contains the configuration of the libp2p connection manager. Connections to peers
+// that take part in an in-flight probe are never closed by the connection manager.
+type ConnectionManager struct {
+	// The number of connections that the connection manager trims down to.
+	LowWater int
+
+	// The number of connections above which the connection manager starts trimming connections.
+	HighWater int
+
+	// The duration for which new connections are not trimmed.
+	GracePeriod time.Duration
+}
+
+// HTTP contains the configuration of the HTTP client with which targets are requested.
+type HTTP struct {
+	// Determines the maximum time a single request may take including reading the response body.
+	Timeout time.Duration `json:",omitempty"`
+
+	// The URL of the proxy through which all requests are sent, e.g., http://proxy.example.com:3128. If empty,
+	// the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string `json:",omitempty"`
+
+	// The path to a PEM file with CA certificates that are trusted in addition to the system ones.
+	CABundle string `json:",omitempty"`
+
+	// The User-Agent header of all requests. Defaults to antares/<version>.
+	UserAgent string `json:",omitempty"`
+
+	// Whether requests should only use HTTP/1.1.
+	DisableHTTP2 bool `json:",omitempty"`
+
+	// Determines how many idle connections are kept open per host for reuse.
+	MaxIdleConnsPerHost int `json:",omitempty"`
+}
+
+// WithDefaults returns a copy of the HTTP configuration where all zero values
+// are replaced by the values of the given defaults.
+func (h HTTP) WithDefaults(defaults HTTP) HTTP {
+	if h.Timeout == 0 {
+		h.Timeout = defaults.Timeout
+	}
+	if h.ProxyURL == "" {
+		h.ProxyURL = defaults.ProxyURL
+	}
+	if h.CABundle == "" {
+		h.CABundle = defaults.CABundle
+	}
+	if h.UserAgent == "" {
+		h.UserAgent = defaults.UserAgent
+	}
+	if !h.DisableHTTP2 {
+		h.DisableHTTP2 = defaults.DisableHTTP2
+	}
+	if h.MaxIdleConnsPerHost == 0 {
+		h.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	return h
+}
+
+// Probe contains the scheduling configuration of the probe of a single target.
+// Zero values fall back to the defaults of the respective target.
+type Probe struct {
+	// Determines the interval at which the target is probed.
+	Rate time.Duration `json:",omitempty"`
+
+	// Determines the maximum time a single probe of the target may take.
+	Timeout time.Duration `json:",omitempty"`
+
+	// Determines how many probes of the target may be in flight at the same time. Each probe
+	// uses a different CID. New probes are still started at most once per Rate. Defaults to 1.
+	Concurrency int `json:",omitempty"`
+
+	// Determines how failed operations against the target are retried.
+	Backoff Backoff
+}
+
+// Backoff contains the configuration of the exponential backoff with which
+// failed operations against a target are retried.
+type Backoff struct {
+	// Determines the wait time before the first retry.
+	InitialInterval time.Duration `json:",omitempty"`
+
+	// Determines the maximum wait time between two retries.
+	MaxInterval time.Duration `json:",omitempty"`
+
+	// Determines after which time no more retries are attempted. Zero retries until the probe times out.
+	// Falls back to the default of the target if not set.
+	MaxElapsedTime *time.Duration `json:",omitempty"`
+
+	// Determines the factor by which the wait time increases after each retry.
+	Multiplier float64 `json:",omitempty"`
+
+	// Determines the relative random jitter that's applied to the wait time. Zero disables the jitter.
+	// Falls back to the default of the target if not set.
+	RandomizationFactor *float64 `json:",omitempty"`
+}
+
+// WithDefaults returns a copy of the probe configuration where all zero values and
+// unset values are replaced by the values of the given defaults.
+func (p Probe) WithDefaults(defaults Probe) Probe {
+	if p.Rate == 0 {
+		p.Rate = defaults.Rate
+	}
+	if p.Timeout == 0 {
+		p.Timeout = defaults.Timeout
+	}
+	if p.Concurrency == 0 {
+		p.Concurrency = defaults.Concurrency
+	}
+	if p.Backoff.InitialInterval == 0 {
+		p.Backoff.InitialInterval = defaults.Backoff.InitialInterval
+	}
+	if p.Backoff.MaxInterval == 0 {
+		p.Backoff.MaxInterval = defaults.Backoff.MaxInterval
+	}
+	if p.Backoff.MaxElapsedTime == nil {
+		p.Backoff.MaxElapsedTime = defaults.Backoff.MaxElapsedTime
+	}
+	if p.Backoff.Multiplier == 0 {
+		p.Backoff.Multiplier = defaults.Backoff.Multiplier
+	}
+	if p.Backoff.RandomizationFactor == nil {
+		p.Backoff.RandomizationFactor = defaults.Backoff.RandomizationFactor
+	}
+	return p
 }
 
 // Init takes the command line argument and tries to read the config file from that directory.
@@ -161,8 +442,33 @@ func Init(c *cli.Context) (*Config, error) {
 	return conf, nil
 }
 
+// Load reads the configuration like Init and applies the command line arguments, but never writes the
+// configuration file, e.g., to validate it. It returns an error if the configuration file doesn't exist.
+func Load(c *cli.Context) (*Config, error) {
+	path := c.String("config")
+	if path == "" {
+		// Only search the xdg file, because xdg.ConfigFile creates its directory
+		var err error
+		path, err = xdg.SearchConfigFile(configFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "search config file")
+		}
+	}
+
+	conf, err := Reload(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read config")
+	}
+
+	// Apply command line argument configurations.
+	conf.apply(c)
+
+	return conf, nil
+}
+
 // Save persists the configuration to disk using the `Path` field.
-// Permissions will be 0o744
+// Permissions will be 0o600 because the file may contain credentials.
+// Secret references are written as they are and never resolved.
 func (c *Config) Save() error {
 	log.Infoln("Saving configuration file to", c.Path)
 
@@ -178,16 +484,53 @@ func (c *Config) Save() error {
 		}
 	}
 
-	return os.WriteFile(c.Path, data, 0o744)
+	if err = os.WriteFile(c.Path, data, 0o600); err != nil {
+		return err
+	}
+
+	// WriteFile only applies the permissions to new files
+	return os.Chmod(c.Path, 0o600)
 }
 
-// String prints the configuration as a json string
+// String prints the configuration as a json string. Credentials that are not
+// references to secrets are redacted.
 func (c *Config) String() string {
-	data, _ := json.MarshalIndent(c, "", "  ")
+	redacted := *c
+	redacted.PrivKeyRaw = nil
+	redacted.Database.Password = redact(c.Database.Password)
+
+	redacted.PinningServices = make([]PinningService, len(c.PinningServices))
+	for i, ps := range c.PinningServices {
+		ps.Authorization = redact(ps.Authorization)
+		redacted.PinningServices[i] = ps
+	}
+
+	redacted.UploadServices = make([]UploadService, len(c.UploadServices))
+	for i, us := range c.UploadServices {
+		us.Authorization = redact(us.Authorization)
+		redacted.UploadServices[i] = us
+	}
+
+	data, _ := json.MarshalIndent(redacted, "", "  ")
 	return fmt.Sprintf("%s", data)
 }
 
+// Reload reads the configuration file at the given path again, e.g., after it was changed while
+// Antares is running. Other than Init, it neither writes the file nor applies command line arguments.
+func Reload(path string) (*Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return parse(path, false)
+}
+
 func read(path string) (*Config, error) {
+	return parse(path, true)
+}
+
+// parse reads the configuration from the file at the given path and applies the overrides from environment
+// variables. If persist is true, JSON configuration files are created or updated with new defaults.
+func parse(path string, persist bool) (*Config, error) {
 	if path == "" {
 		// If no configuration file was given use xdg file.
 		var err error
@@ -197,61 +540,118 @@ func read(path string) (*Config, error) {
 		}
 	}
 
+	format, err := configFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Infoln("Loading configuration from:", path)
 	conf := DefaultConfig
 	conf.Path = path
-	data, err := os.ReadFile(path)
-	if err == nil {
-		err = json.Unmarshal(data, &conf)
+
+	settings := map[string]any{}
+	if _, err = os.Stat(path); err == nil {
+		settings, err = readSettings(path)
 		if err != nil {
-			return nil, errors.Wrap(err, "unmarshal configuration")
+			return nil, errors.Wrap(err, "read configuration")
+		}
+
+		if err = decodeSettings(settings, &conf); err != nil {
+			return nil, errors.Wrap(err, "decode configuration")
 		}
 		conf.Existed = true
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
 
-		if len(conf.PrivKeyRaw) == 0 {
+	if len(conf.PrivKeyRaw) == 0 {
+		if conf.Existed {
 			log.Infoln("Config found but generating new peer identity...")
-			conf.PrivKey, _, err = crypto.GenerateEd25519Key(rand.Reader)
-			if err != nil {
-				return nil, errors.Wrap(err, "generate key pair")
-			}
-
-			conf.PrivKeyRaw, err = crypto.MarshalPrivateKey(conf.PrivKey)
-			if err != nil {
-				return nil, errors.Wrap(err, "raw private key")
-			}
-		} else {
-			conf.PrivKey, err = crypto.UnmarshalPrivateKey(conf.PrivKeyRaw)
-			if err != nil {
-				return nil, errors.Wrap(err, "unmarshal private key")
-			}
 		}
 
-		conf.ListenAddrTCP, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", conf.Host, conf.Port))
+		privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
 		if err != nil {
-			return nil, errors.Wrap(err, "construct IPv4 TCP address")
+			return nil, errors.Wrap(err, "generate key pair")
 		}
 
-		conf.ListenAddrQUIC, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic", conf.Host, conf.Port))
+		conf.PrivKeyRaw, err = crypto.MarshalPrivateKey(privKey)
 		if err != nil {
-			return nil, errors.Wrap(err, "construct IPv4 QUIC address")
+			return nil, errors.Wrap(err, "raw private key")
 		}
 
-		return &conf, conf.Save()
-	} else if !os.IsNotExist(err) {
-		return nil, err
-	} else {
+		// Only JSON configuration files are written by Antares
+		if format != "json" {
+			log.Warnln("Add PrivKeyRaw to the configuration file to keep the peer identity across restarts")
+		}
+	}
 
-		conf.PrivKey, _, err = crypto.GenerateEd25519Key(rand.Reader)
-		if err != nil {
-			return nil, errors.Wrap(err, "generate key pair")
+	// Persist the configuration before the environment variables are applied, so that they don't end up in the file.
+	if persist && format == "json" {
+		if err = conf.Save(); err != nil {
+			return nil, errors.Wrap(err, "save configuration")
 		}
+	}
 
-		conf.PrivKeyRaw, err = crypto.MarshalPrivateKey(conf.PrivKey)
+	// Apply the overrides from environment variables to the file configuration
+	if envSettings, applied := applyEnv(settings, os.Environ()); applied {
+		envConf := DefaultConfig
+		if err = decodeSettings(envSettings, &envConf); err != nil {
+			return nil, errors.Wrap(err, "decode environment variables")
+		}
+
+		if len(envConf.PrivKeyRaw) == 0 {
+			envConf.PrivKeyRaw = conf.PrivKeyRaw
+		}
+		envConf.Path = conf.Path
+		envConf.Existed = conf.Existed
+		conf = envConf
+	}
+
+	conf.PrivKey, err = crypto.UnmarshalPrivateKey(conf.PrivKeyRaw)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal private key")
+	}
+
+	return &conf, nil
+}
+
+// ListenMaddrs returns the multi addresses Antares listens on. If no ListenAddrs are configured, it listens
+// with TCP, QUIC, QUIC v1 and WebTransport on the Port of the Host. If the Host is an IPv4 address, it
+// additionally listens on all IPv6 interfaces.
+func (c *Config) ListenMaddrs() ([]ma.Multiaddr, error) {
+	addrs := c.ListenAddrs
+	if len(addrs) == 0 {
+		ip := net.ParseIP(c.Host)
+		if ip == nil {
+			return nil, fmt.Errorf("host %s is not an IP address", c.Host)
+		}
+
+		if ip.To4() != nil {
+			addrs = append(defaultListenAddrs("ip4", c.Host, c.Port), defaultListenAddrs("ip6", "::", c.Port)...)
+		} else {
+			addrs = defaultListenAddrs("ip6", c.Host, c.Port)
+		}
+	}
+
+	maddrs := make([]ma.Multiaddr, len(addrs))
+	for i, addr := range addrs {
+		maddr, err := ma.NewMultiaddr(addr)
 		if err != nil {
-			return nil, errors.Wrap(err, "raw private key")
+			return nil, errors.Wrapf(err, "parse listen address %s", addr)
 		}
+		maddrs[i] = maddr
+	}
 
-		return &conf, conf.Save()
+	return maddrs, nil
+}
+
+// defaultListenAddrs returns the listen addresses of all supported transports for the given IP.
+func defaultListenAddrs(ipProtocol string, ip string, port int) []string {
+	return []string{
+		fmt.Sprintf("/%s/%s/tcp/%d", ipProtocol, ip, port),
+		fmt.Sprintf("/%s/%s/udp/%d/quic", ipProtocol, ip, port),
+		fmt.Sprintf("/%s/%s/udp/%d/quic-v1", ipProtocol, ip, port),
+		fmt.Sprintf("/%s/%s/udp/%d/quic-v1/webtransport", ipProtocol, ip, port),
 	}
 }
 
@@ -274,6 +674,9 @@ func (c *Config) apply(ctx *cli.Context) {
 	if ctx.IsSet("dry-run") {
 		c.Database.DryRun = ctx.Bool("dry-run")
 	}
+	if ctx.IsSet("auto-migrate") {
+		c.Database.AutoMigrate = ctx.Bool("auto-migrate")
+	}
 	if ctx.IsSet("db-host") {
 		c.Database.Host = ctx.String("db-host")
 	}
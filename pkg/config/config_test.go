@@ -1,14 +1,20 @@
 package config
 
 import (
+	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/adrg/xdg"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dennis-tra/antares/pkg/utils"
 )
 
 func setup(t *testing.T) (Config, func(t *testing.T)) {
@@ -56,6 +62,36 @@ func Test_readConfig_noPath_exists(t *testing.T) {
 	assert.True(t, configLoaded.Existed)
 }
 
+func TestLoad(t *testing.T) {
+	newContext := func(path string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.String("config", path, "")
+		set.Int("port", 0, "")
+		require.NoError(t, set.Parse([]string{"--port", "4000"}))
+		return cli.NewContext(&cli.App{Version: "test"}, set, nil)
+	}
+
+	// A missing file is an error and must not be created
+	path := filepath.Join(t.TempDir(), "config.json")
+	_, err := Load(newContext(path))
+	assert.Error(t, err)
+	assert.NoFileExists(t, path)
+
+	// An existing file without a private key must not be written
+	content := `{"Port": 3000}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	conf, err := Load(newContext(path))
+	require.NoError(t, err)
+	assert.True(t, conf.Existed)
+	assert.Equal(t, 4000, conf.Port)
+	assert.Equal(t, "test", conf.Version)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
 func TestConfig_Save(t *testing.T) {
 	config, teardown := setup(t)
 	defer teardown(t)
@@ -64,4 +100,190 @@ func TestConfig_Save(t *testing.T) {
 	err := config.Save()
 	require.NoError(t, err)
 	assert.False(t, config.Existed)
+
+	info, err := os.Stat(config.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Existing files with lax permissions must be restricted
+	require.NoError(t, os.Chmod(config.Path, 0o744))
+	require.NoError(t, config.Save())
+	info, err = os.Stat(config.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestProbe_WithDefaults(t *testing.T) {
+	defaults := Probe{
+		Rate:        2 * time.Minute,
+		Timeout:     10 * time.Minute,
+		Concurrency: 2,
+		Backoff: Backoff{
+			InitialInterval:     time.Minute,
+			MaxInterval:         5 * time.Minute,
+			MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+			Multiplier:          1.2,
+			RandomizationFactor: utils.Ptr(0.5),
+		},
+	}
+
+	assert.Equal(t, defaults, Probe{}.WithDefaults(defaults))
+
+	conf := Probe{
+		Rate:        time.Hour,
+		Concurrency: 4,
+		Backoff: Backoff{
+			MaxElapsedTime:      utils.Ptr(time.Hour),
+			RandomizationFactor: utils.Ptr(0.0),
+		},
+	}.WithDefaults(defaults)
+
+	assert.Equal(t, time.Hour, conf.Rate)
+	assert.Equal(t, defaults.Timeout, conf.Timeout)
+	assert.Equal(t, 4, conf.Concurrency)
+	assert.Equal(t, defaults.Backoff.InitialInterval, conf.Backoff.InitialInterval)
+	assert.Equal(t, time.Hour, *conf.Backoff.MaxElapsedTime)
+
+	// Zero is a valid value for the jitter and must not be replaced by the default
+	assert.Equal(t, 0.0, *conf.Backoff.RandomizationFactor)
+}
+
+func TestHTTP_WithDefaults(t *testing.T) {
+	defaults := HTTP{
+		Timeout:             time.Minute,
+		ProxyURL:            "http://proxy.example.com:3128",
+		UserAgent:           "antares/test",
+		MaxIdleConnsPerHost: 4,
+	}
+
+	assert.Equal(t, defaults, HTTP{}.WithDefaults(defaults))
+
+	conf := HTTP{
+		Timeout:      time.Hour,
+		CABundle:     "/etc/ssl/corp.pem",
+		DisableHTTP2: true,
+	}.WithDefaults(defaults)
+
+	assert.Equal(t, time.Hour, conf.Timeout)
+	assert.Equal(t, defaults.ProxyURL, conf.ProxyURL)
+	assert.Equal(t, "/etc/ssl/corp.pem", conf.CABundle)
+	assert.Equal(t, defaults.UserAgent, conf.UserAgent)
+	assert.True(t, conf.DisableHTTP2)
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	_, err := Reload(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"Gateways": [{"Name": "ipfs.io", "URL": "https://ipfs.io/ipfs/{cid}"}]}`), 0o600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	conf, err := Reload(path)
+	require.NoError(t, err)
+	assert.True(t, conf.Existed)
+	require.Len(t, conf.Gateways, 1)
+	assert.Equal(t, "ipfs.io", conf.Gateways[0].Name)
+
+	// A reload must not write the file, because that would trigger the next reload
+	reloaded, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, info.ModTime(), reloaded.ModTime())
+	assert.Equal(t, info.Size(), reloaded.Size())
+}
+
+func TestConfig_ListenMaddrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		addrs   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ipv4 host",
+			host: "0.0.0.0",
+			want: []string{
+				"/ip4/0.0.0.0/tcp/2002", "/ip4/0.0.0.0/udp/2002/quic", "/ip4/0.0.0.0/udp/2002/quic-v1", "/ip4/0.0.0.0/udp/2002/quic-v1/webtransport",
+				"/ip6/::/tcp/2002", "/ip6/::/udp/2002/quic", "/ip6/::/udp/2002/quic-v1", "/ip6/::/udp/2002/quic-v1/webtransport",
+			},
+		},
+		{
+			name: "ipv6 host",
+			host: "::1",
+			want: []string{"/ip6/::1/tcp/2002", "/ip6/::1/udp/2002/quic", "/ip6/::1/udp/2002/quic-v1", "/ip6/::1/udp/2002/quic-v1/webtransport"},
+		},
+		{
+			name:    "invalid host",
+			host:    "localhost",
+			wantErr: true,
+		},
+		{
+			name:  "listen addresses",
+			host:  "localhost",
+			addrs: []string{"/ip6/::/udp/4001/quic-v1"},
+			want:  []string{"/ip6/::/udp/4001/quic-v1"},
+		},
+		{
+			name:    "invalid listen addresses",
+			addrs:   []string{"/ip6/::/udp/quic-v1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := DefaultConfig
+			conf.Host = tt.host
+			conf.ListenAddrs = tt.addrs
+
+			maddrs, err := conf.ListenMaddrs()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make([]string, len(maddrs))
+			for i, maddr := range maddrs {
+				got[i] = maddr.String()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNetwork_BootstrapAddrInfos(t *testing.T) {
+	infos, err := Network{}.BootstrapAddrInfos()
+	require.NoError(t, err)
+	assert.NotEmpty(t, infos)
+
+	infos, err = Network{BootstrapPeers: []string{
+		"/ip4/10.0.0.1/tcp/4001/p2p/12D3KooWGRUVh2tf3TYDnEB8jrrD7ojwBxWmu3b1ZXBm9UAGjdzH",
+		"/ip4/10.0.0.1/udp/4001/quic-v1/p2p/12D3KooWGRUVh2tf3TYDnEB8jrrD7ojwBxWmu3b1ZXBm9UAGjdzH",
+	}}.BootstrapAddrInfos()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Len(t, infos[0].Addrs, 2)
+
+	_, err = Network{BootstrapPeers: []string{"/ip4/10.0.0.1/tcp/4001"}}.BootstrapAddrInfos()
+	assert.Error(t, err)
+}
+
+func TestNetwork_SwarmKey(t *testing.T) {
+	psk, err := Network{}.SwarmKey()
+	require.NoError(t, err)
+	assert.Nil(t, psk)
+
+	path := filepath.Join(t.TempDir(), "swarm.key")
+	require.NoError(t, os.WriteFile(path, []byte("/key/swarm/psk/1.0.0/\n/base16/\n"+strings.Repeat("ab", 32)+"\n"), 0o600))
+
+	psk, err = Network{SwarmKeyPath: path}.SwarmKey()
+	require.NoError(t, err)
+	assert.Len(t, psk, 32)
+
+	require.NoError(t, os.WriteFile(path, []byte("/key/swarm/psk/1.0.0/\n/base16/\nab\n"), 0o600))
+	_, err = Network{SwarmKeyPath: path}.SwarmKey()
+	assert.Error(t, err)
 }
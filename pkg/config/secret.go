@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Prefixes of configuration values that reference secrets instead of containing them.
+const (
+	// SecretPrefixEnv references the environment variable with the given name, e.g., env:PINATA_JWT.
+	SecretPrefixEnv = "env:"
+
+	// SecretPrefixFile references the file at the given path, e.g., file:/run/secrets/pinata.
+	SecretPrefixFile = "file:"
+)
+
+// ResolveSecret returns the secret that the given configuration value references. Values without
+// a reference prefix are returned as they are. Trailing newlines of secret files are removed.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretPrefixEnv):
+		name := strings.TrimPrefix(value, SecretPrefixEnv)
+		secret, found := os.LookupEnv(name)
+		if !found {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, SecretPrefixFile):
+		data, err := os.ReadFile(strings.TrimPrefix(value, SecretPrefixFile))
+		if err != nil {
+			return "", errors.Wrap(err, "read secret file")
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	default:
+		return value, nil
+	}
+}
+
+// isSecretRef returns true if the given configuration value references a secret.
+func isSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretPrefixEnv) || strings.HasPrefix(value, SecretPrefixFile)
+}
+
+// redact replaces the given secret configuration value if it isn't a reference.
+func redact(value string) string {
+	if value == "" || isSecretRef(value) {
+		return value
+	}
+	return "<redacted>"
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("ANTARES_TEST_SECRET", "from-env")
+
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "literal", value: "plain", want: "plain"},
+		{name: "empty", value: "", want: ""},
+		{name: "env", value: "env:ANTARES_TEST_SECRET", want: "from-env"},
+		{name: "env unset", value: "env:ANTARES_TEST_UNSET", wantErr: true},
+		{name: "file", value: "file:" + path, want: "from-file"},
+		{name: "file missing", value: "file:" + path + ".missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSecret(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_String_redacts(t *testing.T) {
+	conf := DefaultConfig
+	conf.Database.Password = "db-secret"
+	conf.PinningServices = []PinningService{
+		{Target: "pinata", Authorization: "pinata-secret"},
+		{Target: "psa", Authorization: "env:PSA_TOKEN"},
+	}
+	conf.UploadServices = []UploadService{{Target: "web3", Authorization: "web3-secret"}}
+
+	str := conf.String()
+	assert.NotContains(t, str, "db-secret")
+	assert.NotContains(t, str, "pinata-secret")
+	assert.NotContains(t, str, "web3-secret")
+	assert.Contains(t, str, "env:PSA_TOKEN")
+
+	// The original configuration must not be modified
+	assert.Equal(t, "pinata-secret", conf.PinningServices[0].Authorization)
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of environment variables that override configuration values.
+const EnvPrefix = "ANTARES_"
+
+// The formats of configuration files that Antares can read.
+var configFormats = map[string]string{
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+	".toml": "toml",
+}
+
+// configFormat determines the format of the configuration file at the given path by its extension.
+func configFormat(path string) (string, error) {
+	format, found := configFormats[strings.ToLower(filepath.Ext(path))]
+	if !found {
+		return "", fmt.Errorf("unsupported configuration file format %s", filepath.Ext(path))
+	}
+	return format, nil
+}
+
+// readSettings reads the configuration file at the given path into a nested map with lower-case keys.
+func readSettings(path string) (map[string]any, error) {
+	format, err := configFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType(format)
+	if err = v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	return normalizeSettings(v.AllSettings()).(map[string]any), nil
+}
+
+// normalizeSettings recursively converts all maps to maps with lower-case string keys. Viper
+// leaves maps that are nested in lists untouched, which YAML decodes with interface keys.
+func normalizeSettings(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			m[strings.ToLower(key)] = normalizeSettings(val)
+		}
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, val := range v {
+			m[strings.ToLower(fmt.Sprint(key))] = normalizeSettings(val)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, val := range v {
+			l[i] = normalizeSettings(val)
+		}
+		return l
+	default:
+		return value
+	}
+}
+
+// applyEnv overrides the given settings with all environment variables that start with EnvPrefix.
+// The rest of the variable name is the path to the configuration value with underscores as separators
+// and list indices as numbers, e.g., ANTARES_GATEWAYS_0_URL. Variables whose path conflicts with the
+// structure of the settings are skipped. It returns the updated settings and whether any variable was applied.
+func applyEnv(settings map[string]any, environ []string) (map[string]any, bool) {
+	applied := false
+	for _, env := range environ {
+		key, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(key, EnvPrefix) {
+			continue
+		}
+
+		path := strings.Split(strings.ToLower(strings.TrimPrefix(key, EnvPrefix)), "_")
+		updated, err := setSetting(settings, path, value)
+		if err != nil {
+			log.WithError(err).WithField("env", key).Warnln("Ignoring environment variable")
+			continue
+		}
+		settings = updated.(map[string]any)
+		applied = true
+	}
+
+	return settings, applied
+}
+
+// setSetting sets the value at the given path below the given node and returns the updated node.
+// Missing maps and list entries are created on the way.
+func setSetting(node any, path []string, value string) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	if idx, err := strconv.Atoi(path[0]); err == nil && idx >= 0 {
+		list, ok := node.([]any)
+		if node != nil && !ok {
+			return nil, fmt.Errorf("%s is not a list index", path[0])
+		}
+
+		for len(list) <= idx {
+			list = append(list, nil)
+		}
+
+		child, err := setSetting(list[idx], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		list[idx] = child
+
+		return list, nil
+	}
+
+	m, ok := node.(map[string]any)
+	if node != nil && !ok {
+		return nil, fmt.Errorf("%s is not a field of a list entry or value", path[0])
+	} else if m == nil {
+		m = map[string]any{}
+	}
+
+	child, err := setSetting(m[path[0]], path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	m[path[0]] = child
+
+	return m, nil
+}
+
+// decodeSettings decodes the given settings into the given configuration. Keys are matched
+// case-insensitively and values are converted to the type of the respective field.
+func decodeSettings(settings map[string]any, conf *Config) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           conf,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			stringToDurationHook,
+			stringToBytesHook,
+		),
+	})
+	if err != nil {
+		return errors.Wrap(err, "new decoder")
+	}
+
+	return dec.Decode(settings)
+}
+
+// stringToDurationHook converts strings to durations. Strings can either be a number of
+// nanoseconds like in JSON configuration files or a duration like 5m.
+func stringToDurationHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(time.Duration(0)) {
+		return data, nil
+	}
+
+	if ns, err := strconv.ParseInt(data.(string), 10, 64); err == nil {
+		return time.Duration(ns), nil
+	}
+
+	return time.ParseDuration(data.(string))
+}
+
+// stringToBytesHook decodes base64 strings to bytes like encoding/json does.
+func stringToBytesHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf([]byte{}) {
+		return data, nil
+	}
+
+	return base64.StdEncoding.DecodeString(data.(string))
+}
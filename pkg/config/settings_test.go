@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `
+Port: 3000
+Database:
+  DryRun: true
+Gateways:
+  - Name: ipfs.io
+    URL: https://ipfs.io/ipfs/{cid}
+    Rate: 5m
+    Backoff:
+      Multiplier: 1.5
+PinningServices:
+  - Target: kubo
+    Endpoint: http://127.0.0.1:5001
+    PinOptions:
+      recursive: "false"
+`
+
+const testTOML = `
+Port = 3000
+
+[Database]
+DryRun = true
+
+[[Gateways]]
+Name = "ipfs.io"
+URL = "https://ipfs.io/ipfs/{cid}"
+Rate = "5m"
+
+[Gateways.Backoff]
+Multiplier = 1.5
+
+[[PinningServices]]
+Target = "kubo"
+Endpoint = "http://127.0.0.1:5001"
+
+[PinningServices.PinOptions]
+recursive = "false"
+`
+
+func writeTestConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRead_formats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "yaml", file: "config.yaml", content: testYAML},
+		{name: "yml", file: "config.yml", content: testYAML},
+		{name: "toml", file: "config.toml", content: testTOML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestConfig(t, tt.file, tt.content)
+
+			conf, err := read(path)
+			require.NoError(t, err)
+
+			assert.True(t, conf.Existed)
+			assert.Equal(t, 3000, conf.Port)
+			assert.Equal(t, DefaultConfig.Host, conf.Host)
+			assert.True(t, conf.Database.DryRun)
+			assert.Equal(t, DefaultConfig.Database.Name, conf.Database.Name)
+			require.Len(t, conf.Gateways, 1)
+			assert.Equal(t, "ipfs.io", conf.Gateways[0].Name)
+			assert.Equal(t, "https://ipfs.io/ipfs/{cid}", conf.Gateways[0].URL)
+			assert.Equal(t, 5*time.Minute, conf.Gateways[0].Rate)
+			assert.Equal(t, 1.5, conf.Gateways[0].Backoff.Multiplier)
+			require.Len(t, conf.PinningServices, 1)
+			assert.Equal(t, map[string]string{"recursive": "false"}, conf.PinningServices[0].PinOptions)
+			assert.NotNil(t, conf.PrivKey)
+
+			// Antares only writes JSON configuration files
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(data))
+		})
+	}
+}
+
+func TestRead_unsupportedFormat(t *testing.T) {
+	_, err := read(writeTestConfig(t, "config.ini", "Port=3000"))
+	assert.Error(t, err)
+}
+
+func TestRead_probe(t *testing.T) {
+	path := writeTestConfig(t, "config.yaml", `
+Gateways:
+  - Name: ipfs.io
+    URL: https://ipfs.io/ipfs/{cid}
+    Backoff:
+      MaxElapsedTime: 0s
+      RandomizationFactor: 0
+`)
+
+	conf, err := read(path)
+	require.NoError(t, err)
+	require.Len(t, conf.Gateways, 1)
+	require.NotNil(t, conf.Gateways[0].Backoff.MaxElapsedTime)
+	assert.Zero(t, *conf.Gateways[0].Backoff.MaxElapsedTime)
+	require.NotNil(t, conf.Gateways[0].Backoff.RandomizationFactor)
+	assert.Zero(t, *conf.Gateways[0].Backoff.RandomizationFactor)
+}
+
+func TestRead_env(t *testing.T) {
+	path := writeTestConfig(t, "config.json", `{"Port": 3000, "Gateways": [{"Name": "ipfs.io", "URL": "https://ipfs.io/ipfs/{cid}"}]}`)
+
+	t.Setenv("ANTARES_PORT", "4000")
+	t.Setenv("ANTARES_DATABASE_DRYRUN", "true")
+	t.Setenv("ANTARES_DATABASE_AUTOMIGRATE", "true")
+	t.Setenv("ANTARES_GATEWAYS_0_TIMEOUT", "30s")
+	t.Setenv("ANTARES_GATEWAYS_1_NAME", "dweb.link")
+	t.Setenv("ANTARES_GATEWAYS_1_URL", "https://dweb.link/ipfs/{cid}")
+	t.Setenv("ANTARES_PINNINGSERVICES_0_TARGET", "pinata")
+	t.Setenv("ANTARES_PINNINGSERVICES_0_AUTHORIZATION", "secret")
+	t.Setenv("ANTARES_HTTP_TIMEOUT", "60000000000")
+	t.Setenv("ANTARES_PORT_0", "conflicting")
+
+	conf, err := read(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4000, conf.Port)
+	assert.True(t, conf.Database.DryRun)
+	assert.True(t, conf.Database.AutoMigrate)
+	assert.Equal(t, time.Minute, conf.HTTP.Timeout)
+	require.Len(t, conf.Gateways, 2)
+	assert.Equal(t, "ipfs.io", conf.Gateways[0].Name)
+	assert.Equal(t, 30*time.Second, conf.Gateways[0].Timeout)
+	assert.Equal(t, "dweb.link", conf.Gateways[1].Name)
+	assert.Equal(t, "https://dweb.link/ipfs/{cid}", conf.Gateways[1].URL)
+	require.Len(t, conf.PinningServices, 1)
+	assert.Equal(t, "pinata", conf.PinningServices[0].Target)
+
+	// Environment variables must not be persisted, especially not secrets
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+	assert.NotContains(t, string(data), "dweb.link")
+
+	// The persisted peer identity must be used
+	saved, err := read(path)
+	require.NoError(t, err)
+	assert.Equal(t, conf.PrivKeyRaw, saved.PrivKeyRaw)
+}
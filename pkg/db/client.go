@@ -36,6 +36,11 @@ func InitClient(conf *config.Config) (*Client, error) {
 		return nil, errors.Wrap(err, "register ocsql")
 	}
 
+	password, err := config.ResolveSecret(conf.Database.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve database password")
+	}
+
 	// Open database handle
 	srcName := fmt.Sprintf(
 		"host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
@@ -43,7 +48,7 @@ func InitClient(conf *config.Config) (*Client, error) {
 		conf.Database.Port,
 		conf.Database.Name,
 		conf.Database.User,
-		conf.Database.Password,
+		password,
 		conf.Database.SSLMode,
 	)
 	dbh, err := sql.Open(driverName, srcName)
@@ -59,6 +64,38 @@ func InitClient(conf *config.Config) (*Client, error) {
 	return &Client{dbh}, nil
 }
 
+// Close closes the database handle.
+func (c *Client) Close() error {
+	return c.dbh.Close()
+}
+
 func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	return c.dbh.BeginTx(ctx, opts)
 }
+
+// The following methods let the Client satisfy the boil.ContextExecutor interface,
+// so that it can be used to execute single queries outside of a transaction.
+
+func (c *Client) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.dbh.Exec(query, args...)
+}
+
+func (c *Client) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.dbh.Query(query, args...)
+}
+
+func (c *Client) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.dbh.QueryRow(query, args...)
+}
+
+func (c *Client) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.dbh.ExecContext(ctx, query, args...)
+}
+
+func (c *Client) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.dbh.QueryContext(ctx, query, args...)
+}
+
+func (c *Client) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.dbh.QueryRowContext(ctx, query, args...)
+}
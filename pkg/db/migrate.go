@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/migrations"
+)
+
+// MigrationStatus describes the state of the database schema.
+type MigrationStatus struct {
+	// Version is the version of the latest applied migration (0 if none was applied).
+	Version uint
+
+	// Dirty is true if the latest migration failed and the schema needs manual fixing.
+	Dirty bool
+
+	// Available are the versions of all embedded migrations in ascending order.
+	Available []uint
+}
+
+// Pending returns the versions of all embedded migrations that were not applied yet.
+func (s *MigrationStatus) Pending() []uint {
+	var pending []uint
+	for _, v := range s.Available {
+		if v > s.Version {
+			pending = append(pending, v)
+		}
+	}
+	return pending
+}
+
+// newMigrate initializes a migration instance from the embedded migration files
+// that operates on a dedicated connection of the client's database. Closing the
+// instance only releases that connection and leaves the client usable.
+func (c *Client) newMigrate(ctx context.Context) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "open embedded migrations")
+	}
+
+	conn, err := c.dbh.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "acquire database connection")
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrap(err, "create migration driver")
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		return nil, errors.Wrap(err, "create migrate instance")
+	}
+	m.Log = migrateLogger{}
+
+	return m, nil
+}
+
+// MigrateUp applies all embedded migrations that were not applied yet.
+func (c *Client) MigrateUp(ctx context.Context) error {
+	m, err := c.newMigrate(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeMigrate(m)
+
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return errors.Wrap(err, "migrate up")
+	}
+
+	return nil
+}
+
+// MigrateDown rolls back the given number of migrations. If steps is zero or less,
+// all migrations are rolled back.
+func (c *Client) MigrateDown(ctx context.Context, steps int) error {
+	m, err := c.newMigrate(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeMigrate(m)
+
+	if steps > 0 {
+		err = m.Steps(-steps)
+	} else {
+		err = m.Down()
+	}
+
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return errors.Wrap(err, "migrate down")
+	}
+
+	return nil
+}
+
+// MigrationStatus returns the current version of the database schema and all available migrations.
+func (c *Client) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	m, err := c.newMigrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeMigrate(m)
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, errors.Wrap(err, "get migration version")
+	}
+
+	available, err := availableMigrations()
+	if err != nil {
+		return nil, errors.Wrap(err, "list available migrations")
+	}
+
+	return &MigrationStatus{
+		Version:   version,
+		Dirty:     dirty,
+		Available: available,
+	}, nil
+}
+
+func closeMigrate(m *migrate.Migrate) {
+	if srcErr, dbErr := m.Close(); srcErr != nil || dbErr != nil {
+		log.WithField("srcErr", srcErr).WithField("dbErr", dbErr).Warnln("Error closing migrate instance")
+	}
+}
+
+// availableMigrations returns the versions of all embedded up migrations in ascending order.
+func availableMigrations() ([]uint, error) {
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint, 0, len(files))
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse version of %s", file)
+		}
+		versions = append(versions, uint(version))
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	return versions, nil
+}
+
+// migrateLogger forwards the log messages of golang-migrate to logrus.
+type migrateLogger struct{}
+
+func (l migrateLogger) Printf(format string, v ...interface{}) {
+	log.Infof(strings.TrimSpace(format), v...)
+}
+
+func (l migrateLogger) Verbose() bool {
+	return log.IsLevelEnabled(log.DebugLevel)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/migrations"
+)
+
+// OpenSQLite opens the SQLite database at the given path and applies all pending migrations. The
+// database file is created if it doesn't exist. In contrast to postgres, migrations are always applied,
+// because the database is embedded and exclusively used by Antares.
+func OpenSQLite(path string) (*sql.DB, error) {
+	log.WithField("path", path).Infoln("Opening SQLite database")
+
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	dbh, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+
+	// SQLite only supports a single writer at a time, so we serialize all access.
+	dbh.SetMaxOpenConns(1)
+
+	if err = migrateSQLite(dbh); err != nil {
+		_ = dbh.Close()
+		return nil, err
+	}
+
+	return dbh, nil
+}
+
+// migrateSQLite applies the embedded SQLite migrations to the given database.
+func migrateSQLite(dbh *sql.DB) error {
+	src, err := iofs.New(migrations.SQLiteFS, "sqlite")
+	if err != nil {
+		return errors.Wrap(err, "open embedded migrations")
+	}
+
+	driver, err := sqlite.WithInstance(dbh, &sqlite.Config{})
+	if err != nil {
+		return errors.Wrap(err, "create migration driver")
+	}
+
+	// The migrate instance isn't closed, because that would close the database handle.
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
+	if err != nil {
+		return errors.Wrap(err, "create migrate instance")
+	}
+	m.Log = migrateLogger{}
+
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return errors.Wrap(err, "migrate up")
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"contrib.go.opencensus.io/integrations/ocsql"
+	"github.com/libp2p/go-libp2p/p2p/host/resource-manager/obs"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -19,6 +20,9 @@ func RegisterMetrics() error {
 	if err := view.Register(DefaultStartViews...); err != nil {
 		return errors.Wrap(err, "register antares default views")
 	}
+	if err := view.Register(obs.DefaultViews...); err != nil {
+		return errors.Wrap(err, "register resource manager views")
+	}
 	return nil
 }
 
@@ -52,16 +56,26 @@ func ListenAndServe(host string, port int) error {
 
 // Keys
 var (
-	KeyTargetName, _ = tag.NewKey("target_name")
-	KeyTargetType, _ = tag.NewKey("target_type")
+	KeyTargetName, _   = tag.NewKey("target_name")
+	KeyTargetType, _   = tag.NewKey("target_type")
+	KeyVerification, _ = tag.NewKey("verification")
 )
 
 // Measures
 var (
 	ProbeCount = stats.Int64("probe_count", "Number probes performed", stats.UnitDimensionless)
 	TrackCount = stats.Int64("track_count", "Number tracked peers", stats.UnitDimensionless)
+
+	VerificationCount = stats.Int64("verification_count", "Number of verifications of retrieved content", stats.UnitDimensionless)
+
+	ProvideDuration   = stats.Float64("provide_duration", "Time it took to provide a probe CID in the DHT", stats.UnitMilliseconds)
+	OperationDuration = stats.Float64("operation_duration", "Time it took for the target operation to complete", stats.UnitMilliseconds)
+	FirstWantLatency  = stats.Float64("first_want_latency", "Time from the start of the target operation until the first Bitswap want arrived", stats.UnitMilliseconds)
 )
 
+// latencyDistribution contains the histogram bucket boundaries in milliseconds for all latency measures.
+var latencyDistribution = view.Distribution(100, 250, 500, 1_000, 2_500, 5_000, 10_000, 30_000, 60_000, 120_000, 300_000, 600_000)
+
 // Views
 var (
 	ProbeCountView = &view.View{
@@ -74,10 +88,34 @@ var (
 		TagKeys:     []tag.Key{KeyTargetName, KeyTargetType},
 		Aggregation: view.Count(),
 	}
+	VerificationCountView = &view.View{
+		Measure:     VerificationCount,
+		TagKeys:     []tag.Key{KeyTargetName, KeyTargetType, KeyVerification},
+		Aggregation: view.Count(),
+	}
+	ProvideDurationView = &view.View{
+		Measure:     ProvideDuration,
+		TagKeys:     []tag.Key{KeyTargetName, KeyTargetType},
+		Aggregation: latencyDistribution,
+	}
+	OperationDurationView = &view.View{
+		Measure:     OperationDuration,
+		TagKeys:     []tag.Key{KeyTargetName, KeyTargetType},
+		Aggregation: latencyDistribution,
+	}
+	FirstWantLatencyView = &view.View{
+		Measure:     FirstWantLatency,
+		TagKeys:     []tag.Key{KeyTargetName, KeyTargetType},
+		Aggregation: latencyDistribution,
+	}
 )
 
 // DefaultStartViews with all views in it.
 var DefaultStartViews = []*view.View{
 	ProbeCountView,
 	TrackCountView,
+	VerificationCountView,
+	ProvideDurationView,
+	OperationDurationView,
+	FirstWantLatencyView,
 }
@@ -12,57 +12,100 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("Lookups", testLookups)
 	t.Run("Peers", testPeers)
+	t.Run("Probes", testProbes)
+	t.Run("Sightings", testSightings)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("Lookups", testLookupsDelete)
 	t.Run("Peers", testPeersDelete)
+	t.Run("Probes", testProbesDelete)
+	t.Run("Sightings", testSightingsDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("Lookups", testLookupsQueryDeleteAll)
 	t.Run("Peers", testPeersQueryDeleteAll)
+	t.Run("Probes", testProbesQueryDeleteAll)
+	t.Run("Sightings", testSightingsQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("Lookups", testLookupsSliceDeleteAll)
 	t.Run("Peers", testPeersSliceDeleteAll)
+	t.Run("Probes", testProbesSliceDeleteAll)
+	t.Run("Sightings", testSightingsSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("Lookups", testLookupsExists)
 	t.Run("Peers", testPeersExists)
+	t.Run("Probes", testProbesExists)
+	t.Run("Sightings", testSightingsExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("Lookups", testLookupsFind)
 	t.Run("Peers", testPeersFind)
+	t.Run("Probes", testProbesFind)
+	t.Run("Sightings", testSightingsFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("Lookups", testLookupsBind)
 	t.Run("Peers", testPeersBind)
+	t.Run("Probes", testProbesBind)
+	t.Run("Sightings", testSightingsBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("Lookups", testLookupsOne)
 	t.Run("Peers", testPeersOne)
+	t.Run("Probes", testProbesOne)
+	t.Run("Sightings", testSightingsOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("Lookups", testLookupsAll)
 	t.Run("Peers", testPeersAll)
+	t.Run("Probes", testProbesAll)
+	t.Run("Sightings", testSightingsAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("Lookups", testLookupsCount)
 	t.Run("Peers", testPeersCount)
+	t.Run("Probes", testProbesCount)
+	t.Run("Sightings", testSightingsCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("Lookups", testLookupsHooks)
 	t.Run("Peers", testPeersHooks)
+	t.Run("Probes", testProbesHooks)
+	t.Run("Sightings", testSightingsHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("Lookups", testLookupsInsert)
+	t.Run("Lookups", testLookupsInsertWhitelist)
 	t.Run("Peers", testPeersInsert)
 	t.Run("Peers", testPeersInsertWhitelist)
+	t.Run("Probes", testProbesInsert)
+	t.Run("Probes", testProbesInsertWhitelist)
+	t.Run("Sightings", testSightingsInsert)
+	t.Run("Sightings", testSightingsInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOne(t *testing.T) {}
+func TestToOne(t *testing.T) {
+	t.Run("LookupToProbeUsingProbe", testLookupToOneProbeUsingProbe)
+	t.Run("SightingToPeerUsingPeer", testSightingToOnePeerUsingPeer)
+	t.Run("SightingToProbeUsingProbe", testSightingToOneProbeUsingProbe)
+}
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
@@ -70,11 +113,19 @@ func TestOneToOne(t *testing.T) {}
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToMany(t *testing.T) {}
+func TestToMany(t *testing.T) {
+	t.Run("PeerToSightings", testPeerToManySightings)
+	t.Run("ProbeToLookups", testProbeToManyLookups)
+	t.Run("ProbeToSightings", testProbeToManySightings)
+}
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneSet(t *testing.T) {}
+func TestToOneSet(t *testing.T) {
+	t.Run("LookupToProbeUsingLookups", testLookupToOneSetOpProbeUsingProbe)
+	t.Run("SightingToPeerUsingSightings", testSightingToOneSetOpPeerUsingPeer)
+	t.Run("SightingToProbeUsingSightings", testSightingToOneSetOpProbeUsingProbe)
+}
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
@@ -90,7 +141,11 @@ func TestOneToOneRemove(t *testing.T) {}
 
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyAdd(t *testing.T) {}
+func TestToManyAdd(t *testing.T) {
+	t.Run("PeerToSightings", testPeerToManyAddOpSightings)
+	t.Run("ProbeToLookups", testProbeToManyAddOpLookups)
+	t.Run("ProbeToSightings", testProbeToManyAddOpSightings)
+}
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
@@ -101,21 +156,36 @@ func TestToManySet(t *testing.T) {}
 func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
+	t.Run("Lookups", testLookupsReload)
 	t.Run("Peers", testPeersReload)
+	t.Run("Probes", testProbesReload)
+	t.Run("Sightings", testSightingsReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("Lookups", testLookupsReloadAll)
 	t.Run("Peers", testPeersReloadAll)
+	t.Run("Probes", testProbesReloadAll)
+	t.Run("Sightings", testSightingsReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("Lookups", testLookupsSelect)
 	t.Run("Peers", testPeersSelect)
+	t.Run("Probes", testProbesSelect)
+	t.Run("Sightings", testSightingsSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("Lookups", testLookupsUpdate)
 	t.Run("Peers", testPeersUpdate)
+	t.Run("Probes", testProbesUpdate)
+	t.Run("Sightings", testSightingsUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("Lookups", testLookupsSliceUpdateAll)
 	t.Run("Peers", testPeersSliceUpdateAll)
+	t.Run("Probes", testProbesSliceUpdateAll)
+	t.Run("Sightings", testSightingsSliceUpdateAll)
 }
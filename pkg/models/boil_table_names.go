@@ -4,7 +4,13 @@
 package models
 
 var TableNames = struct {
-	Peers string
+	Lookups   string
+	Peers     string
+	Probes    string
+	Sightings string
 }{
-	Peers: "peers",
+	Lookups:   "lookups",
+	Peers:     "peers",
+	Probes:    "probes",
+	Sightings: "sightings",
 }
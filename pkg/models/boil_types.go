@@ -50,3 +50,87 @@ func makeCacheKey(cols boil.Columns, nzDefaults []string) string {
 	strmangle.PutBuffer(buf)
 	return str
 }
+
+// Enum values for LookupType
+const (
+	LookupTypeGetProviders string = "get_providers"
+	LookupTypeFindNode     string = "find_node"
+)
+
+func AllLookupType() []string {
+	return []string{
+		LookupTypeGetProviders,
+		LookupTypeFindNode,
+	}
+}
+
+// Enum values for ProbeOutcome
+const (
+	ProbeOutcomeSuccess  string = "success"
+	ProbeOutcomeTimeout  string = "timeout"
+	ProbeOutcomeError    string = "error"
+	ProbeOutcomeCanceled string = "canceled"
+)
+
+func AllProbeOutcome() []string {
+	return []string{
+		ProbeOutcomeSuccess,
+		ProbeOutcomeTimeout,
+		ProbeOutcomeError,
+		ProbeOutcomeCanceled,
+	}
+}
+
+// Enum values for ProbeVerification
+const (
+	ProbeVerificationValid     string = "valid"
+	ProbeVerificationCorrupted string = "corrupted"
+	ProbeVerificationStale     string = "stale"
+)
+
+func AllProbeVerification() []string {
+	return []string{
+		ProbeVerificationValid,
+		ProbeVerificationCorrupted,
+		ProbeVerificationStale,
+	}
+}
+
+// Enum values for WantType
+const (
+	WantTypeBlock string = "block"
+	WantTypeHave  string = "have"
+)
+
+func AllWantType() []string {
+	return []string{
+		WantTypeBlock,
+		WantTypeHave,
+	}
+}
+
+// Enum values for IPFamily
+const (
+	IPFamilyIpv4 string = "ipv4"
+	IPFamilyIpv6 string = "ipv6"
+)
+
+func AllIPFamily() []string {
+	return []string{
+		IPFamilyIpv4,
+		IPFamilyIpv6,
+	}
+}
+
+// Enum values for ConnDirection
+const (
+	ConnDirectionInbound  string = "inbound"
+	ConnDirectionOutbound string = "outbound"
+)
+
+func AllConnDirection() []string {
+	return []string{
+		ConnDirectionInbound,
+		ConnDirectionOutbound,
+	}
+}
@@ -0,0 +1,842 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testLookups(t *testing.T) {
+	t.Parallel()
+
+	query := Lookups()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testLookupsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLookupsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Lookups().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLookupsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LookupSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLookupsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := LookupExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Lookup exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected LookupExists to return true, but got false.")
+	}
+}
+
+func testLookupsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	lookupFound, err := FindLookup(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if lookupFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testLookupsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Lookups().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLookupsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Lookups().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testLookupsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	lookupOne := &Lookup{}
+	lookupTwo := &Lookup{}
+	if err = randomize.Struct(seed, lookupOne, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+	if err = randomize.Struct(seed, lookupTwo, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = lookupOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = lookupTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Lookups().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testLookupsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	lookupOne := &Lookup{}
+	lookupTwo := &Lookup{}
+	if err = randomize.Struct(seed, lookupOne, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+	if err = randomize.Struct(seed, lookupTwo, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = lookupOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = lookupTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func lookupBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func lookupAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Lookup) error {
+	*o = Lookup{}
+	return nil
+}
+
+func testLookupsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &Lookup{}
+	o := &Lookup{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, lookupDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Lookup object: %s", err)
+	}
+
+	AddLookupHook(boil.BeforeInsertHook, lookupBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	lookupBeforeInsertHooks = []LookupHook{}
+
+	AddLookupHook(boil.AfterInsertHook, lookupAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	lookupAfterInsertHooks = []LookupHook{}
+
+	AddLookupHook(boil.AfterSelectHook, lookupAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	lookupAfterSelectHooks = []LookupHook{}
+
+	AddLookupHook(boil.BeforeUpdateHook, lookupBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	lookupBeforeUpdateHooks = []LookupHook{}
+
+	AddLookupHook(boil.AfterUpdateHook, lookupAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	lookupAfterUpdateHooks = []LookupHook{}
+
+	AddLookupHook(boil.BeforeDeleteHook, lookupBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	lookupBeforeDeleteHooks = []LookupHook{}
+
+	AddLookupHook(boil.AfterDeleteHook, lookupAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	lookupAfterDeleteHooks = []LookupHook{}
+
+	AddLookupHook(boil.BeforeUpsertHook, lookupBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	lookupBeforeUpsertHooks = []LookupHook{}
+
+	AddLookupHook(boil.AfterUpsertHook, lookupAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	lookupAfterUpsertHooks = []LookupHook{}
+}
+
+func testLookupsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLookupsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(lookupColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLookupToOneProbeUsingProbe(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local Lookup
+	var foreign Probe
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.ProbeID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Probe().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := LookupSlice{&local}
+	if err = local.L.LoadProbe(ctx, tx, false, (*[]*Lookup)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Probe == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Probe = nil
+	if err = local.L.LoadProbe(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Probe == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testLookupToOneSetOpProbeUsingProbe(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Lookup
+	var b, c Probe
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, lookupDBTypes, false, strmangle.SetComplement(lookupPrimaryKeyColumns, lookupColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Probe{&b, &c} {
+		err = a.SetProbe(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Probe != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Lookups[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.ProbeID != x.ID {
+			t.Error("foreign key was wrong value", a.ProbeID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ProbeID))
+		reflect.Indirect(reflect.ValueOf(&a.ProbeID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ProbeID != x.ID {
+			t.Error("foreign key was wrong value", a.ProbeID, x.ID)
+		}
+	}
+}
+
+func testLookupsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLookupsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LookupSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLookupsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Lookups().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	lookupDBTypes = map[string]string{`ID`: `bigint`, `ProbeID`: `bigint`, `MultiHash`: `text`, `AgentVersion`: `text`, `MultiAddresses`: `ARRAYtext`, `Type`: `enum.lookup_type('get_providers','find_node')`, `SeenAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`}
+	_             = bytes.MinRead
+)
+
+func testLookupsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(lookupPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(lookupAllColumns) == len(lookupPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testLookupsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(lookupAllColumns) == len(lookupPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lookup{}
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, lookupDBTypes, true, lookupPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(lookupAllColumns, lookupPrimaryKeyColumns) {
+		fields = lookupAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			lookupAllColumns,
+			lookupPrimaryKeyColumns,
+		)
+		fields = strmangle.SetComplement(fields, lookupGeneratedColumns)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := LookupSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testLookupsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(lookupAllColumns) == len(lookupPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Lookup{}
+	if err = randomize.Struct(seed, &o, lookupDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Lookup: %s", err)
+	}
+
+	count, err := Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, lookupDBTypes, false, lookupPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lookup struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Lookup: %s", err)
+	}
+
+	count, err = Lookups().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,1537 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Probe is an object representing the database table.
+type Probe struct {
+	ID                int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	TargetType        string            `boil:"target_type" json:"target_type" toml:"target_type" yaml:"target_type"`
+	TargetName        string            `boil:"target_name" json:"target_name" toml:"target_name" yaml:"target_name"`
+	Cid               string            `boil:"cid" json:"cid" toml:"cid" yaml:"cid"`
+	Outcome           null.String       `boil:"outcome" json:"outcome,omitempty" toml:"outcome" yaml:"outcome,omitempty"`
+	Error             null.String       `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	StartedAt         time.Time         `boil:"started_at" json:"started_at" toml:"started_at" yaml:"started_at"`
+	EndedAt           null.Time         `boil:"ended_at" json:"ended_at,omitempty" toml:"ended_at" yaml:"ended_at,omitempty"`
+	CreatedAt         time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ProvideDuration   null.String       `boil:"provide_duration" json:"provide_duration,omitempty" toml:"provide_duration" yaml:"provide_duration,omitempty"`
+	OperationDuration null.String       `boil:"operation_duration" json:"operation_duration,omitempty" toml:"operation_duration" yaml:"operation_duration,omitempty"`
+	FirstWantLatency  null.String       `boil:"first_want_latency" json:"first_want_latency,omitempty" toml:"first_want_latency" yaml:"first_want_latency,omitempty"`
+	Verification      null.String       `boil:"verification" json:"verification,omitempty" toml:"verification" yaml:"verification,omitempty"`
+	HTTPStatusCode    null.Int          `boil:"http_status_code" json:"http_status_code,omitempty" toml:"http_status_code" yaml:"http_status_code,omitempty"`
+	HTTPURL           null.String       `boil:"http_url" json:"http_url,omitempty" toml:"http_url" yaml:"http_url,omitempty"`
+	HTTPRedirects     types.StringArray `boil:"http_redirects" json:"http_redirects,omitempty" toml:"http_redirects" yaml:"http_redirects,omitempty"`
+	HTTPHeaders       null.JSON         `boil:"http_headers" json:"http_headers,omitempty" toml:"http_headers" yaml:"http_headers,omitempty"`
+	HTTPTLSVersion    null.String       `boil:"http_tls_version" json:"http_tls_version,omitempty" toml:"http_tls_version" yaml:"http_tls_version,omitempty"`
+	HTTPBytes         null.Int64        `boil:"http_bytes" json:"http_bytes,omitempty" toml:"http_bytes" yaml:"http_bytes,omitempty"`
+
+	R *probeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L probeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ProbeColumns = struct {
+	ID                string
+	TargetType        string
+	TargetName        string
+	Cid               string
+	Outcome           string
+	Error             string
+	StartedAt         string
+	EndedAt           string
+	CreatedAt         string
+	ProvideDuration   string
+	OperationDuration string
+	FirstWantLatency  string
+	Verification      string
+	HTTPStatusCode    string
+	HTTPURL           string
+	HTTPRedirects     string
+	HTTPHeaders       string
+	HTTPTLSVersion    string
+	HTTPBytes         string
+}{
+	ID:                "id",
+	TargetType:        "target_type",
+	TargetName:        "target_name",
+	Cid:               "cid",
+	Outcome:           "outcome",
+	Error:             "error",
+	StartedAt:         "started_at",
+	EndedAt:           "ended_at",
+	CreatedAt:         "created_at",
+	ProvideDuration:   "provide_duration",
+	OperationDuration: "operation_duration",
+	FirstWantLatency:  "first_want_latency",
+	Verification:      "verification",
+	HTTPStatusCode:    "http_status_code",
+	HTTPURL:           "http_url",
+	HTTPRedirects:     "http_redirects",
+	HTTPHeaders:       "http_headers",
+	HTTPTLSVersion:    "http_tls_version",
+	HTTPBytes:         "http_bytes",
+}
+
+var ProbeTableColumns = struct {
+	ID                string
+	TargetType        string
+	TargetName        string
+	Cid               string
+	Outcome           string
+	Error             string
+	StartedAt         string
+	EndedAt           string
+	CreatedAt         string
+	ProvideDuration   string
+	OperationDuration string
+	FirstWantLatency  string
+	Verification      string
+	HTTPStatusCode    string
+	HTTPURL           string
+	HTTPRedirects     string
+	HTTPHeaders       string
+	HTTPTLSVersion    string
+	HTTPBytes         string
+}{
+	ID:                "probes.id",
+	TargetType:        "probes.target_type",
+	TargetName:        "probes.target_name",
+	Cid:               "probes.cid",
+	Outcome:           "probes.outcome",
+	Error:             "probes.error",
+	StartedAt:         "probes.started_at",
+	EndedAt:           "probes.ended_at",
+	CreatedAt:         "probes.created_at",
+	ProvideDuration:   "probes.provide_duration",
+	OperationDuration: "probes.operation_duration",
+	FirstWantLatency:  "probes.first_want_latency",
+	Verification:      "probes.verification",
+	HTTPStatusCode:    "probes.http_status_code",
+	HTTPURL:           "probes.http_url",
+	HTTPRedirects:     "probes.http_redirects",
+	HTTPHeaders:       "probes.http_headers",
+	HTTPTLSVersion:    "probes.http_tls_version",
+	HTTPBytes:         "probes.http_bytes",
+}
+
+// Generated where
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_JSON struct{ field string }
+
+func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_JSON) NEQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_JSON) LT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_JSON) LTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_JSON) GT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var ProbeWhere = struct {
+	ID                whereHelperint64
+	TargetType        whereHelperstring
+	TargetName        whereHelperstring
+	Cid               whereHelperstring
+	Outcome           whereHelpernull_String
+	Error             whereHelpernull_String
+	StartedAt         whereHelpertime_Time
+	EndedAt           whereHelpernull_Time
+	CreatedAt         whereHelpertime_Time
+	ProvideDuration   whereHelpernull_String
+	OperationDuration whereHelpernull_String
+	FirstWantLatency  whereHelpernull_String
+	Verification      whereHelpernull_String
+	HTTPStatusCode    whereHelpernull_Int
+	HTTPURL           whereHelpernull_String
+	HTTPRedirects     whereHelpertypes_StringArray
+	HTTPHeaders       whereHelpernull_JSON
+	HTTPTLSVersion    whereHelpernull_String
+	HTTPBytes         whereHelpernull_Int64
+}{
+	ID:                whereHelperint64{field: "\"probes\".\"id\""},
+	TargetType:        whereHelperstring{field: "\"probes\".\"target_type\""},
+	TargetName:        whereHelperstring{field: "\"probes\".\"target_name\""},
+	Cid:               whereHelperstring{field: "\"probes\".\"cid\""},
+	Outcome:           whereHelpernull_String{field: "\"probes\".\"outcome\""},
+	Error:             whereHelpernull_String{field: "\"probes\".\"error\""},
+	StartedAt:         whereHelpertime_Time{field: "\"probes\".\"started_at\""},
+	EndedAt:           whereHelpernull_Time{field: "\"probes\".\"ended_at\""},
+	CreatedAt:         whereHelpertime_Time{field: "\"probes\".\"created_at\""},
+	ProvideDuration:   whereHelpernull_String{field: "\"probes\".\"provide_duration\""},
+	OperationDuration: whereHelpernull_String{field: "\"probes\".\"operation_duration\""},
+	FirstWantLatency:  whereHelpernull_String{field: "\"probes\".\"first_want_latency\""},
+	Verification:      whereHelpernull_String{field: "\"probes\".\"verification\""},
+	HTTPStatusCode:    whereHelpernull_Int{field: "\"probes\".\"http_status_code\""},
+	HTTPURL:           whereHelpernull_String{field: "\"probes\".\"http_url\""},
+	HTTPRedirects:     whereHelpertypes_StringArray{field: "\"probes\".\"http_redirects\""},
+	HTTPHeaders:       whereHelpernull_JSON{field: "\"probes\".\"http_headers\""},
+	HTTPTLSVersion:    whereHelpernull_String{field: "\"probes\".\"http_tls_version\""},
+	HTTPBytes:         whereHelpernull_Int64{field: "\"probes\".\"http_bytes\""},
+}
+
+// ProbeRels is where relationship names are stored.
+var ProbeRels = struct {
+	Lookups   string
+	Sightings string
+}{
+	Lookups:   "Lookups",
+	Sightings: "Sightings",
+}
+
+// probeR is where relationships are stored.
+type probeR struct {
+	Lookups   LookupSlice   `boil:"Lookups" json:"Lookups" toml:"Lookups" yaml:"Lookups"`
+	Sightings SightingSlice `boil:"Sightings" json:"Sightings" toml:"Sightings" yaml:"Sightings"`
+}
+
+// NewStruct creates a new relationship struct
+func (*probeR) NewStruct() *probeR {
+	return &probeR{}
+}
+
+func (r *probeR) GetLookups() LookupSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Lookups
+}
+
+func (r *probeR) GetSightings() SightingSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Sightings
+}
+
+// probeL is where Load methods for each relationship are stored.
+type probeL struct{}
+
+var (
+	probeAllColumns            = []string{"id", "target_type", "target_name", "cid", "outcome", "error", "started_at", "ended_at", "created_at", "provide_duration", "operation_duration", "first_want_latency", "verification", "http_status_code", "http_url", "http_redirects", "http_headers", "http_tls_version", "http_bytes"}
+	probeColumnsWithoutDefault = []string{"target_type", "target_name", "cid", "started_at", "created_at"}
+	probeColumnsWithDefault    = []string{"id", "outcome", "error", "ended_at", "provide_duration", "operation_duration", "first_want_latency", "verification", "http_status_code", "http_url", "http_redirects", "http_headers", "http_tls_version", "http_bytes"}
+	probePrimaryKeyColumns     = []string{"id"}
+	probeGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// ProbeSlice is an alias for a slice of pointers to Probe.
+	// This should almost always be used instead of []Probe.
+	ProbeSlice []*Probe
+	// ProbeHook is the signature for custom Probe hook methods
+	ProbeHook func(context.Context, boil.ContextExecutor, *Probe) error
+
+	probeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	probeType                 = reflect.TypeOf(&Probe{})
+	probeMapping              = queries.MakeStructMapping(probeType)
+	probePrimaryKeyMapping, _ = queries.BindMapping(probeType, probeMapping, probePrimaryKeyColumns)
+	probeInsertCacheMut       sync.RWMutex
+	probeInsertCache          = make(map[string]insertCache)
+	probeUpdateCacheMut       sync.RWMutex
+	probeUpdateCache          = make(map[string]updateCache)
+	probeUpsertCacheMut       sync.RWMutex
+	probeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var probeAfterSelectHooks []ProbeHook
+
+var probeBeforeInsertHooks []ProbeHook
+var probeAfterInsertHooks []ProbeHook
+
+var probeBeforeUpdateHooks []ProbeHook
+var probeAfterUpdateHooks []ProbeHook
+
+var probeBeforeDeleteHooks []ProbeHook
+var probeAfterDeleteHooks []ProbeHook
+
+var probeBeforeUpsertHooks []ProbeHook
+var probeAfterUpsertHooks []ProbeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Probe) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Probe) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Probe) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Probe) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Probe) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Probe) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Probe) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Probe) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Probe) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range probeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddProbeHook registers your hook function for all future operations.
+func AddProbeHook(hookPoint boil.HookPoint, probeHook ProbeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		probeAfterSelectHooks = append(probeAfterSelectHooks, probeHook)
+	case boil.BeforeInsertHook:
+		probeBeforeInsertHooks = append(probeBeforeInsertHooks, probeHook)
+	case boil.AfterInsertHook:
+		probeAfterInsertHooks = append(probeAfterInsertHooks, probeHook)
+	case boil.BeforeUpdateHook:
+		probeBeforeUpdateHooks = append(probeBeforeUpdateHooks, probeHook)
+	case boil.AfterUpdateHook:
+		probeAfterUpdateHooks = append(probeAfterUpdateHooks, probeHook)
+	case boil.BeforeDeleteHook:
+		probeBeforeDeleteHooks = append(probeBeforeDeleteHooks, probeHook)
+	case boil.AfterDeleteHook:
+		probeAfterDeleteHooks = append(probeAfterDeleteHooks, probeHook)
+	case boil.BeforeUpsertHook:
+		probeBeforeUpsertHooks = append(probeBeforeUpsertHooks, probeHook)
+	case boil.AfterUpsertHook:
+		probeAfterUpsertHooks = append(probeAfterUpsertHooks, probeHook)
+	}
+}
+
+// One returns a single probe record from the query.
+func (q probeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Probe, error) {
+	o := &Probe{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for probes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Probe records from the query.
+func (q probeQuery) All(ctx context.Context, exec boil.ContextExecutor) (ProbeSlice, error) {
+	var o []*Probe
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Probe slice")
+	}
+
+	if len(probeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Probe records in the query.
+func (q probeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count probes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q probeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if probes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Lookups retrieves all the lookup's Lookups with an executor.
+func (o *Probe) Lookups(mods ...qm.QueryMod) lookupQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"lookups\".\"probe_id\"=?", o.ID),
+	)
+
+	return Lookups(queryMods...)
+}
+
+// Sightings retrieves all the sighting's Sightings with an executor.
+func (o *Probe) Sightings(mods ...qm.QueryMod) sightingQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"sightings\".\"probe_id\"=?", o.ID),
+	)
+
+	return Sightings(queryMods...)
+}
+
+// LoadLookups allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (probeL) LoadLookups(ctx context.Context, e boil.ContextExecutor, singular bool, maybeProbe interface{}, mods queries.Applicator) error {
+	var slice []*Probe
+	var object *Probe
+
+	if singular {
+		var ok bool
+		object, ok = maybeProbe.(*Probe)
+		if !ok {
+			object = new(Probe)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeProbe)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeProbe))
+			}
+		}
+	} else {
+		s, ok := maybeProbe.(*[]*Probe)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeProbe)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeProbe))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &probeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &probeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`lookups`),
+		qm.WhereIn(`lookups.probe_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load lookups")
+	}
+
+	var resultSlice []*Lookup
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice lookups")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on lookups")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for lookups")
+	}
+
+	if len(lookupAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Lookups = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &lookupR{}
+			}
+			foreign.R.Probe = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProbeID {
+				local.R.Lookups = append(local.R.Lookups, foreign)
+				if foreign.R == nil {
+					foreign.R = &lookupR{}
+				}
+				foreign.R.Probe = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSightings allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (probeL) LoadSightings(ctx context.Context, e boil.ContextExecutor, singular bool, maybeProbe interface{}, mods queries.Applicator) error {
+	var slice []*Probe
+	var object *Probe
+
+	if singular {
+		var ok bool
+		object, ok = maybeProbe.(*Probe)
+		if !ok {
+			object = new(Probe)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeProbe)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeProbe))
+			}
+		}
+	} else {
+		s, ok := maybeProbe.(*[]*Probe)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeProbe)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeProbe))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &probeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &probeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`sightings`),
+		qm.WhereIn(`sightings.probe_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load sightings")
+	}
+
+	var resultSlice []*Sighting
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice sightings")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on sightings")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for sightings")
+	}
+
+	if len(sightingAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Sightings = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &sightingR{}
+			}
+			foreign.R.Probe = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProbeID {
+				local.R.Sightings = append(local.R.Sightings, foreign)
+				if foreign.R == nil {
+					foreign.R = &sightingR{}
+				}
+				foreign.R.Probe = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddLookups adds the given related objects to the existing relationships
+// of the probe, optionally inserting them as new records.
+// Appends related to o.R.Lookups.
+// Sets related.R.Probe appropriately.
+func (o *Probe) AddLookups(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Lookup) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProbeID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"lookups\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"probe_id"}),
+				strmangle.WhereClause("\"", "\"", 2, lookupPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProbeID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &probeR{
+			Lookups: related,
+		}
+	} else {
+		o.R.Lookups = append(o.R.Lookups, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &lookupR{
+				Probe: o,
+			}
+		} else {
+			rel.R.Probe = o
+		}
+	}
+	return nil
+}
+
+// AddSightings adds the given related objects to the existing relationships
+// of the probe, optionally inserting them as new records.
+// Appends related to o.R.Sightings.
+// Sets related.R.Probe appropriately.
+func (o *Probe) AddSightings(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Sighting) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProbeID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"sightings\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"probe_id"}),
+				strmangle.WhereClause("\"", "\"", 2, sightingPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProbeID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &probeR{
+			Sightings: related,
+		}
+	} else {
+		o.R.Sightings = append(o.R.Sightings, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &sightingR{
+				Probe: o,
+			}
+		} else {
+			rel.R.Probe = o
+		}
+	}
+	return nil
+}
+
+// Probes retrieves all the records using an executor.
+func Probes(mods ...qm.QueryMod) probeQuery {
+	mods = append(mods, qm.From("\"probes\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"probes\".*"})
+	}
+
+	return probeQuery{q}
+}
+
+// FindProbe retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindProbe(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Probe, error) {
+	probeObj := &Probe{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"probes\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, probeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from probes")
+	}
+
+	if err = probeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return probeObj, err
+	}
+
+	return probeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Probe) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no probes provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(probeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	probeInsertCacheMut.RLock()
+	cache, cached := probeInsertCache[key]
+	probeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			probeAllColumns,
+			probeColumnsWithDefault,
+			probeColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, probeGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(probeType, probeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(probeType, probeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"probes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"probes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into probes")
+	}
+
+	if !cached {
+		probeInsertCacheMut.Lock()
+		probeInsertCache[key] = cache
+		probeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Probe.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Probe) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	probeUpdateCacheMut.RLock()
+	cache, cached := probeUpdateCache[key]
+	probeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			probeAllColumns,
+			probePrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, probeGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update probes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"probes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, probePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(probeType, probeMapping, append(wl, probePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update probes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for probes")
+	}
+
+	if !cached {
+		probeUpdateCacheMut.Lock()
+		probeUpdateCache[key] = cache
+		probeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q probeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for probes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for probes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ProbeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), probePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"probes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, probePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in probe slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all probe")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Probe) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no probes provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(probeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	probeUpsertCacheMut.RLock()
+	cache, cached := probeUpsertCache[key]
+	probeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			probeAllColumns,
+			probeColumnsWithDefault,
+			probeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			probeAllColumns,
+			probePrimaryKeyColumns,
+		)
+
+		insert = strmangle.SetComplement(insert, probeGeneratedColumns)
+		update = strmangle.SetComplement(update, probeGeneratedColumns)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert probes, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(probePrimaryKeyColumns))
+			copy(conflict, probePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"probes\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(probeType, probeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(probeType, probeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert probes")
+	}
+
+	if !cached {
+		probeUpsertCacheMut.Lock()
+		probeUpsertCache[key] = cache
+		probeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Probe record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Probe) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Probe provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), probePrimaryKeyMapping)
+	sql := "DELETE FROM \"probes\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from probes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for probes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q probeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no probeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from probes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for probes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ProbeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(probeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), probePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"probes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, probePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from probe slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for probes")
+	}
+
+	if len(probeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Probe) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindProbe(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ProbeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ProbeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), probePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"probes\".* FROM \"probes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, probePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ProbeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ProbeExists checks if the Probe row exists.
+func ProbeExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"probes\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if probes exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1040 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testProbes(t *testing.T) {
+	t.Parallel()
+
+	query := Probes()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testProbesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testProbesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Probes().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testProbesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ProbeSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testProbesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ProbeExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Probe exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ProbeExists to return true, but got false.")
+	}
+}
+
+func testProbesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	probeFound, err := FindProbe(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if probeFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testProbesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Probes().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testProbesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Probes().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testProbesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	probeOne := &Probe{}
+	probeTwo := &Probe{}
+	if err = randomize.Struct(seed, probeOne, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+	if err = randomize.Struct(seed, probeTwo, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = probeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = probeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Probes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testProbesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	probeOne := &Probe{}
+	probeTwo := &Probe{}
+	if err = randomize.Struct(seed, probeOne, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+	if err = randomize.Struct(seed, probeTwo, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = probeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = probeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func probeBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func probeAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Probe) error {
+	*o = Probe{}
+	return nil
+}
+
+func testProbesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &Probe{}
+	o := &Probe{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, probeDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Probe object: %s", err)
+	}
+
+	AddProbeHook(boil.BeforeInsertHook, probeBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	probeBeforeInsertHooks = []ProbeHook{}
+
+	AddProbeHook(boil.AfterInsertHook, probeAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	probeAfterInsertHooks = []ProbeHook{}
+
+	AddProbeHook(boil.AfterSelectHook, probeAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	probeAfterSelectHooks = []ProbeHook{}
+
+	AddProbeHook(boil.BeforeUpdateHook, probeBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	probeBeforeUpdateHooks = []ProbeHook{}
+
+	AddProbeHook(boil.AfterUpdateHook, probeAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	probeAfterUpdateHooks = []ProbeHook{}
+
+	AddProbeHook(boil.BeforeDeleteHook, probeBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	probeBeforeDeleteHooks = []ProbeHook{}
+
+	AddProbeHook(boil.AfterDeleteHook, probeAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	probeAfterDeleteHooks = []ProbeHook{}
+
+	AddProbeHook(boil.BeforeUpsertHook, probeBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	probeBeforeUpsertHooks = []ProbeHook{}
+
+	AddProbeHook(boil.AfterUpsertHook, probeAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	probeAfterUpsertHooks = []ProbeHook{}
+}
+
+func testProbesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testProbesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(probeColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testProbeToManyLookups(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Probe
+	var b, c Lookup
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = randomize.Struct(seed, &b, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, lookupDBTypes, false, lookupColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+
+	b.ProbeID = a.ID
+	c.ProbeID = a.ID
+
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := a.Lookups().All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bFound, cFound := false, false
+	for _, v := range check {
+		if v.ProbeID == b.ProbeID {
+			bFound = true
+		}
+		if v.ProbeID == c.ProbeID {
+			cFound = true
+		}
+	}
+
+	if !bFound {
+		t.Error("expected to find b")
+	}
+	if !cFound {
+		t.Error("expected to find c")
+	}
+
+	slice := ProbeSlice{&a}
+	if err = a.L.LoadLookups(ctx, tx, false, (*[]*Probe)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Lookups); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	a.R.Lookups = nil
+	if err = a.L.LoadLookups(ctx, tx, true, &a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Lookups); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	if t.Failed() {
+		t.Logf("%#v", check)
+	}
+}
+
+func testProbeToManySightings(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Probe
+	var b, c Sighting
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = randomize.Struct(seed, &b, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+
+	b.ProbeID = a.ID
+	c.ProbeID = a.ID
+
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := a.Sightings().All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bFound, cFound := false, false
+	for _, v := range check {
+		if v.ProbeID == b.ProbeID {
+			bFound = true
+		}
+		if v.ProbeID == c.ProbeID {
+			cFound = true
+		}
+	}
+
+	if !bFound {
+		t.Error("expected to find b")
+	}
+	if !cFound {
+		t.Error("expected to find c")
+	}
+
+	slice := ProbeSlice{&a}
+	if err = a.L.LoadSightings(ctx, tx, false, (*[]*Probe)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Sightings); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	a.R.Sightings = nil
+	if err = a.L.LoadSightings(ctx, tx, true, &a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Sightings); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	if t.Failed() {
+		t.Logf("%#v", check)
+	}
+}
+
+func testProbeToManyAddOpLookups(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Probe
+	var b, c, d, e Lookup
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Lookup{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, lookupDBTypes, false, strmangle.SetComplement(lookupPrimaryKeyColumns, lookupColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	foreignersSplitByInsertion := [][]*Lookup{
+		{&b, &c},
+		{&d, &e},
+	}
+
+	for i, x := range foreignersSplitByInsertion {
+		err = a.AddLookups(ctx, tx, i != 0, x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := x[0]
+		second := x[1]
+
+		if a.ID != first.ProbeID {
+			t.Error("foreign key was wrong value", a.ID, first.ProbeID)
+		}
+		if a.ID != second.ProbeID {
+			t.Error("foreign key was wrong value", a.ID, second.ProbeID)
+		}
+
+		if first.R.Probe != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+		if second.R.Probe != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+
+		if a.R.Lookups[i*2] != first {
+			t.Error("relationship struct slice not set to correct value")
+		}
+		if a.R.Lookups[i*2+1] != second {
+			t.Error("relationship struct slice not set to correct value")
+		}
+
+		count, err := a.Lookups().Count(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64((i + 1) * 2); count != want {
+			t.Error("want", want, "got", count)
+		}
+	}
+}
+func testProbeToManyAddOpSightings(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Probe
+	var b, c, d, e Sighting
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Sighting{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, sightingDBTypes, false, strmangle.SetComplement(sightingPrimaryKeyColumns, sightingColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	foreignersSplitByInsertion := [][]*Sighting{
+		{&b, &c},
+		{&d, &e},
+	}
+
+	for i, x := range foreignersSplitByInsertion {
+		err = a.AddSightings(ctx, tx, i != 0, x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := x[0]
+		second := x[1]
+
+		if a.ID != first.ProbeID {
+			t.Error("foreign key was wrong value", a.ID, first.ProbeID)
+		}
+		if a.ID != second.ProbeID {
+			t.Error("foreign key was wrong value", a.ID, second.ProbeID)
+		}
+
+		if first.R.Probe != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+		if second.R.Probe != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+
+		if a.R.Sightings[i*2] != first {
+			t.Error("relationship struct slice not set to correct value")
+		}
+		if a.R.Sightings[i*2+1] != second {
+			t.Error("relationship struct slice not set to correct value")
+		}
+
+		count, err := a.Sightings().Count(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64((i + 1) * 2); count != want {
+			t.Error("want", want, "got", count)
+		}
+	}
+}
+
+func testProbesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testProbesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ProbeSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testProbesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Probes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	probeDBTypes = map[string]string{`ID`: `bigint`, `TargetType`: `text`, `TargetName`: `text`, `Cid`: `text`, `Outcome`: `enum.probe_outcome('success','timeout','error','canceled')`, `Error`: `text`, `StartedAt`: `timestamp with time zone`, `EndedAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `ProvideDuration`: `interval`, `OperationDuration`: `interval`, `FirstWantLatency`: `interval`, `Verification`: `enum.probe_verification('valid','corrupted','stale')`, `HTTPStatusCode`: `integer`, `HTTPURL`: `text`, `HTTPRedirects`: `ARRAYtext`, `HTTPHeaders`: `jsonb`, `HTTPTLSVersion`: `text`, `HTTPBytes`: `bigint`}
+	_            = bytes.MinRead
+)
+
+func testProbesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(probePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(probeAllColumns) == len(probePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testProbesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(probeAllColumns) == len(probePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Probe{}
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, probeDBTypes, true, probePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(probeAllColumns, probePrimaryKeyColumns) {
+		fields = probeAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			probeAllColumns,
+			probePrimaryKeyColumns,
+		)
+		fields = strmangle.SetComplement(fields, probeGeneratedColumns)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ProbeSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testProbesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(probeAllColumns) == len(probePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Probe{}
+	if err = randomize.Struct(seed, &o, probeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Probe: %s", err)
+	}
+
+	count, err := Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, probeDBTypes, false, probePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Probe: %s", err)
+	}
+
+	count, err = Probes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
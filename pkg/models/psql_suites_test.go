@@ -6,5 +6,11 @@ package models
 import "testing"
 
 func TestUpsert(t *testing.T) {
+	t.Run("Lookups", testLookupsUpsert)
+
 	t.Run("Peers", testPeersUpsert)
+
+	t.Run("Probes", testProbesUpsert)
+
+	t.Run("Sightings", testSightingsUpsert)
 }
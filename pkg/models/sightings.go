@@ -0,0 +1,1480 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Sighting is an object representing the database table.
+type Sighting struct {
+	ID               int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ProbeID          int64             `boil:"probe_id" json:"probe_id" toml:"probe_id" yaml:"probe_id"`
+	PeerID           int64             `boil:"peer_id" json:"peer_id" toml:"peer_id" yaml:"peer_id"`
+	AgentVersion     null.String       `boil:"agent_version" json:"agent_version,omitempty" toml:"agent_version" yaml:"agent_version,omitempty"`
+	Protocols        types.StringArray `boil:"protocols" json:"protocols,omitempty" toml:"protocols" yaml:"protocols,omitempty"`
+	MultiAddresses   types.StringArray `boil:"multi_addresses" json:"multi_addresses" toml:"multi_addresses" yaml:"multi_addresses"`
+	IPAddresses      types.StringArray `boil:"ip_addresses" json:"ip_addresses" toml:"ip_addresses" yaml:"ip_addresses"`
+	Countries        types.StringArray `boil:"countries" json:"countries" toml:"countries" yaml:"countries"`
+	Continents       types.StringArray `boil:"continents" json:"continents" toml:"continents" yaml:"continents"`
+	Asns             types.Int64Array  `boil:"asns" json:"asns" toml:"asns" yaml:"asns"`
+	SeenAt           time.Time         `boil:"seen_at" json:"seen_at" toml:"seen_at" yaml:"seen_at"`
+	CreatedAt        time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	WantType         null.String       `boil:"want_type" json:"want_type,omitempty" toml:"want_type" yaml:"want_type,omitempty"`
+	WantPriority     null.Int          `boil:"want_priority" json:"want_priority,omitempty" toml:"want_priority" yaml:"want_priority,omitempty"`
+	SendDontHave     null.Bool         `boil:"send_dont_have" json:"send_dont_have,omitempty" toml:"send_dont_have" yaml:"send_dont_have,omitempty"`
+	CanceledAt       null.Time         `boil:"canceled_at" json:"canceled_at,omitempty" toml:"canceled_at" yaml:"canceled_at,omitempty"`
+	BlockSentAt      null.Time         `boil:"block_sent_at" json:"block_sent_at,omitempty" toml:"block_sent_at" yaml:"block_sent_at,omitempty"`
+	Transport        null.String       `boil:"transport" json:"transport,omitempty" toml:"transport" yaml:"transport,omitempty"`
+	IPFamily         null.String       `boil:"ip_family" json:"ip_family,omitempty" toml:"ip_family" yaml:"ip_family,omitempty"`
+	Identified       null.Bool         `boil:"identified" json:"identified,omitempty" toml:"identified" yaml:"identified,omitempty"`
+	Direction        null.String       `boil:"direction" json:"direction,omitempty" toml:"direction" yaml:"direction,omitempty"`
+	SecurityProtocol null.String       `boil:"security_protocol" json:"security_protocol,omitempty" toml:"security_protocol" yaml:"security_protocol,omitempty"`
+	Muxer            null.String       `boil:"muxer" json:"muxer,omitempty" toml:"muxer" yaml:"muxer,omitempty"`
+	ConnOpenedAt     null.Time         `boil:"conn_opened_at" json:"conn_opened_at,omitempty" toml:"conn_opened_at" yaml:"conn_opened_at,omitempty"`
+	Relayed          null.Bool         `boil:"relayed" json:"relayed,omitempty" toml:"relayed" yaml:"relayed,omitempty"`
+	PingRTT          null.String       `boil:"ping_rtt" json:"ping_rtt,omitempty" toml:"ping_rtt" yaml:"ping_rtt,omitempty"`
+
+	R *sightingR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L sightingL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SightingColumns = struct {
+	ID               string
+	ProbeID          string
+	PeerID           string
+	AgentVersion     string
+	Protocols        string
+	MultiAddresses   string
+	IPAddresses      string
+	Countries        string
+	Continents       string
+	Asns             string
+	SeenAt           string
+	CreatedAt        string
+	WantType         string
+	WantPriority     string
+	SendDontHave     string
+	CanceledAt       string
+	BlockSentAt      string
+	Transport        string
+	IPFamily         string
+	Identified       string
+	Direction        string
+	SecurityProtocol string
+	Muxer            string
+	ConnOpenedAt     string
+	Relayed          string
+	PingRTT          string
+}{
+	ID:               "id",
+	ProbeID:          "probe_id",
+	PeerID:           "peer_id",
+	AgentVersion:     "agent_version",
+	Protocols:        "protocols",
+	MultiAddresses:   "multi_addresses",
+	IPAddresses:      "ip_addresses",
+	Countries:        "countries",
+	Continents:       "continents",
+	Asns:             "asns",
+	SeenAt:           "seen_at",
+	CreatedAt:        "created_at",
+	WantType:         "want_type",
+	WantPriority:     "want_priority",
+	SendDontHave:     "send_dont_have",
+	CanceledAt:       "canceled_at",
+	BlockSentAt:      "block_sent_at",
+	Transport:        "transport",
+	IPFamily:         "ip_family",
+	Identified:       "identified",
+	Direction:        "direction",
+	SecurityProtocol: "security_protocol",
+	Muxer:            "muxer",
+	ConnOpenedAt:     "conn_opened_at",
+	Relayed:          "relayed",
+	PingRTT:          "ping_rtt",
+}
+
+var SightingTableColumns = struct {
+	ID               string
+	ProbeID          string
+	PeerID           string
+	AgentVersion     string
+	Protocols        string
+	MultiAddresses   string
+	IPAddresses      string
+	Countries        string
+	Continents       string
+	Asns             string
+	SeenAt           string
+	CreatedAt        string
+	WantType         string
+	WantPriority     string
+	SendDontHave     string
+	CanceledAt       string
+	BlockSentAt      string
+	Transport        string
+	IPFamily         string
+	Identified       string
+	Direction        string
+	SecurityProtocol string
+	Muxer            string
+	ConnOpenedAt     string
+	Relayed          string
+	PingRTT          string
+}{
+	ID:               "sightings.id",
+	ProbeID:          "sightings.probe_id",
+	PeerID:           "sightings.peer_id",
+	AgentVersion:     "sightings.agent_version",
+	Protocols:        "sightings.protocols",
+	MultiAddresses:   "sightings.multi_addresses",
+	IPAddresses:      "sightings.ip_addresses",
+	Countries:        "sightings.countries",
+	Continents:       "sightings.continents",
+	Asns:             "sightings.asns",
+	SeenAt:           "sightings.seen_at",
+	CreatedAt:        "sightings.created_at",
+	WantType:         "sightings.want_type",
+	WantPriority:     "sightings.want_priority",
+	SendDontHave:     "sightings.send_dont_have",
+	CanceledAt:       "sightings.canceled_at",
+	BlockSentAt:      "sightings.block_sent_at",
+	Transport:        "sightings.transport",
+	IPFamily:         "sightings.ip_family",
+	Identified:       "sightings.identified",
+	Direction:        "sightings.direction",
+	SecurityProtocol: "sightings.security_protocol",
+	Muxer:            "sightings.muxer",
+	ConnOpenedAt:     "sightings.conn_opened_at",
+	Relayed:          "sightings.relayed",
+	PingRTT:          "sightings.ping_rtt",
+}
+
+// Generated where
+
+type whereHelpernull_Bool struct{ field string }
+
+func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bool) NEQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bool) LT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bool) LTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bool) GT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var SightingWhere = struct {
+	ID               whereHelperint64
+	ProbeID          whereHelperint64
+	PeerID           whereHelperint64
+	AgentVersion     whereHelpernull_String
+	Protocols        whereHelpertypes_StringArray
+	MultiAddresses   whereHelpertypes_StringArray
+	IPAddresses      whereHelpertypes_StringArray
+	Countries        whereHelpertypes_StringArray
+	Continents       whereHelpertypes_StringArray
+	Asns             whereHelpertypes_Int64Array
+	SeenAt           whereHelpertime_Time
+	CreatedAt        whereHelpertime_Time
+	WantType         whereHelpernull_String
+	WantPriority     whereHelpernull_Int
+	SendDontHave     whereHelpernull_Bool
+	CanceledAt       whereHelpernull_Time
+	BlockSentAt      whereHelpernull_Time
+	Transport        whereHelpernull_String
+	IPFamily         whereHelpernull_String
+	Identified       whereHelpernull_Bool
+	Direction        whereHelpernull_String
+	SecurityProtocol whereHelpernull_String
+	Muxer            whereHelpernull_String
+	ConnOpenedAt     whereHelpernull_Time
+	Relayed          whereHelpernull_Bool
+	PingRTT          whereHelpernull_String
+}{
+	ID:               whereHelperint64{field: "\"sightings\".\"id\""},
+	ProbeID:          whereHelperint64{field: "\"sightings\".\"probe_id\""},
+	PeerID:           whereHelperint64{field: "\"sightings\".\"peer_id\""},
+	AgentVersion:     whereHelpernull_String{field: "\"sightings\".\"agent_version\""},
+	Protocols:        whereHelpertypes_StringArray{field: "\"sightings\".\"protocols\""},
+	MultiAddresses:   whereHelpertypes_StringArray{field: "\"sightings\".\"multi_addresses\""},
+	IPAddresses:      whereHelpertypes_StringArray{field: "\"sightings\".\"ip_addresses\""},
+	Countries:        whereHelpertypes_StringArray{field: "\"sightings\".\"countries\""},
+	Continents:       whereHelpertypes_StringArray{field: "\"sightings\".\"continents\""},
+	Asns:             whereHelpertypes_Int64Array{field: "\"sightings\".\"asns\""},
+	SeenAt:           whereHelpertime_Time{field: "\"sightings\".\"seen_at\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"sightings\".\"created_at\""},
+	WantType:         whereHelpernull_String{field: "\"sightings\".\"want_type\""},
+	WantPriority:     whereHelpernull_Int{field: "\"sightings\".\"want_priority\""},
+	SendDontHave:     whereHelpernull_Bool{field: "\"sightings\".\"send_dont_have\""},
+	CanceledAt:       whereHelpernull_Time{field: "\"sightings\".\"canceled_at\""},
+	BlockSentAt:      whereHelpernull_Time{field: "\"sightings\".\"block_sent_at\""},
+	Transport:        whereHelpernull_String{field: "\"sightings\".\"transport\""},
+	IPFamily:         whereHelpernull_String{field: "\"sightings\".\"ip_family\""},
+	Identified:       whereHelpernull_Bool{field: "\"sightings\".\"identified\""},
+	Direction:        whereHelpernull_String{field: "\"sightings\".\"direction\""},
+	SecurityProtocol: whereHelpernull_String{field: "\"sightings\".\"security_protocol\""},
+	Muxer:            whereHelpernull_String{field: "\"sightings\".\"muxer\""},
+	ConnOpenedAt:     whereHelpernull_Time{field: "\"sightings\".\"conn_opened_at\""},
+	Relayed:          whereHelpernull_Bool{field: "\"sightings\".\"relayed\""},
+	PingRTT:          whereHelpernull_String{field: "\"sightings\".\"ping_rtt\""},
+}
+
+// SightingRels is where relationship names are stored.
+var SightingRels = struct {
+	Peer  string
+	Probe string
+}{
+	Peer:  "Peer",
+	Probe: "Probe",
+}
+
+// sightingR is where relationships are stored.
+type sightingR struct {
+	Peer  *Peer  `boil:"Peer" json:"Peer" toml:"Peer" yaml:"Peer"`
+	Probe *Probe `boil:"Probe" json:"Probe" toml:"Probe" yaml:"Probe"`
+}
+
+// NewStruct creates a new relationship struct
+func (*sightingR) NewStruct() *sightingR {
+	return &sightingR{}
+}
+
+func (r *sightingR) GetPeer() *Peer {
+	if r == nil {
+		return nil
+	}
+	return r.Peer
+}
+
+func (r *sightingR) GetProbe() *Probe {
+	if r == nil {
+		return nil
+	}
+	return r.Probe
+}
+
+// sightingL is where Load methods for each relationship are stored.
+type sightingL struct{}
+
+var (
+	sightingAllColumns            = []string{"id", "probe_id", "peer_id", "agent_version", "protocols", "multi_addresses", "ip_addresses", "countries", "continents", "asns", "seen_at", "created_at", "want_type", "want_priority", "send_dont_have", "canceled_at", "block_sent_at", "transport", "ip_family", "identified", "direction", "security_protocol", "muxer", "conn_opened_at", "relayed", "ping_rtt"}
+	sightingColumnsWithoutDefault = []string{"probe_id", "peer_id", "multi_addresses", "ip_addresses", "countries", "continents", "asns", "seen_at", "created_at"}
+	sightingColumnsWithDefault    = []string{"id", "agent_version", "protocols", "want_type", "want_priority", "send_dont_have", "canceled_at", "block_sent_at", "transport", "ip_family", "identified", "direction", "security_protocol", "muxer", "conn_opened_at", "relayed", "ping_rtt"}
+	sightingPrimaryKeyColumns     = []string{"id"}
+	sightingGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// SightingSlice is an alias for a slice of pointers to Sighting.
+	// This should almost always be used instead of []Sighting.
+	SightingSlice []*Sighting
+	// SightingHook is the signature for custom Sighting hook methods
+	SightingHook func(context.Context, boil.ContextExecutor, *Sighting) error
+
+	sightingQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	sightingType                 = reflect.TypeOf(&Sighting{})
+	sightingMapping              = queries.MakeStructMapping(sightingType)
+	sightingPrimaryKeyMapping, _ = queries.BindMapping(sightingType, sightingMapping, sightingPrimaryKeyColumns)
+	sightingInsertCacheMut       sync.RWMutex
+	sightingInsertCache          = make(map[string]insertCache)
+	sightingUpdateCacheMut       sync.RWMutex
+	sightingUpdateCache          = make(map[string]updateCache)
+	sightingUpsertCacheMut       sync.RWMutex
+	sightingUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var sightingAfterSelectHooks []SightingHook
+
+var sightingBeforeInsertHooks []SightingHook
+var sightingAfterInsertHooks []SightingHook
+
+var sightingBeforeUpdateHooks []SightingHook
+var sightingAfterUpdateHooks []SightingHook
+
+var sightingBeforeDeleteHooks []SightingHook
+var sightingAfterDeleteHooks []SightingHook
+
+var sightingBeforeUpsertHooks []SightingHook
+var sightingAfterUpsertHooks []SightingHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Sighting) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Sighting) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Sighting) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Sighting) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Sighting) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Sighting) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Sighting) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Sighting) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Sighting) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range sightingAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSightingHook registers your hook function for all future operations.
+func AddSightingHook(hookPoint boil.HookPoint, sightingHook SightingHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		sightingAfterSelectHooks = append(sightingAfterSelectHooks, sightingHook)
+	case boil.BeforeInsertHook:
+		sightingBeforeInsertHooks = append(sightingBeforeInsertHooks, sightingHook)
+	case boil.AfterInsertHook:
+		sightingAfterInsertHooks = append(sightingAfterInsertHooks, sightingHook)
+	case boil.BeforeUpdateHook:
+		sightingBeforeUpdateHooks = append(sightingBeforeUpdateHooks, sightingHook)
+	case boil.AfterUpdateHook:
+		sightingAfterUpdateHooks = append(sightingAfterUpdateHooks, sightingHook)
+	case boil.BeforeDeleteHook:
+		sightingBeforeDeleteHooks = append(sightingBeforeDeleteHooks, sightingHook)
+	case boil.AfterDeleteHook:
+		sightingAfterDeleteHooks = append(sightingAfterDeleteHooks, sightingHook)
+	case boil.BeforeUpsertHook:
+		sightingBeforeUpsertHooks = append(sightingBeforeUpsertHooks, sightingHook)
+	case boil.AfterUpsertHook:
+		sightingAfterUpsertHooks = append(sightingAfterUpsertHooks, sightingHook)
+	}
+}
+
+// One returns a single sighting record from the query.
+func (q sightingQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Sighting, error) {
+	o := &Sighting{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for sightings")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Sighting records from the query.
+func (q sightingQuery) All(ctx context.Context, exec boil.ContextExecutor) (SightingSlice, error) {
+	var o []*Sighting
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Sighting slice")
+	}
+
+	if len(sightingAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Sighting records in the query.
+func (q sightingQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count sightings rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q sightingQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if sightings exists")
+	}
+
+	return count > 0, nil
+}
+
+// Peer pointed to by the foreign key.
+func (o *Sighting) Peer(mods ...qm.QueryMod) peerQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.PeerID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Peers(queryMods...)
+}
+
+// Probe pointed to by the foreign key.
+func (o *Sighting) Probe(mods ...qm.QueryMod) probeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ProbeID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Probes(queryMods...)
+}
+
+// LoadPeer allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (sightingL) LoadPeer(ctx context.Context, e boil.ContextExecutor, singular bool, maybeSighting interface{}, mods queries.Applicator) error {
+	var slice []*Sighting
+	var object *Sighting
+
+	if singular {
+		var ok bool
+		object, ok = maybeSighting.(*Sighting)
+		if !ok {
+			object = new(Sighting)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeSighting)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeSighting))
+			}
+		}
+	} else {
+		s, ok := maybeSighting.(*[]*Sighting)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeSighting)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeSighting))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &sightingR{}
+		}
+		args = append(args, object.PeerID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &sightingR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PeerID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PeerID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`peers`),
+		qm.WhereIn(`peers.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Peer")
+	}
+
+	var resultSlice []*Peer
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Peer")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for peers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for peers")
+	}
+
+	if len(sightingAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Peer = foreign
+		if foreign.R == nil {
+			foreign.R = &peerR{}
+		}
+		foreign.R.Sightings = append(foreign.R.Sightings, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PeerID == foreign.ID {
+				local.R.Peer = foreign
+				if foreign.R == nil {
+					foreign.R = &peerR{}
+				}
+				foreign.R.Sightings = append(foreign.R.Sightings, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProbe allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (sightingL) LoadProbe(ctx context.Context, e boil.ContextExecutor, singular bool, maybeSighting interface{}, mods queries.Applicator) error {
+	var slice []*Sighting
+	var object *Sighting
+
+	if singular {
+		var ok bool
+		object, ok = maybeSighting.(*Sighting)
+		if !ok {
+			object = new(Sighting)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeSighting)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeSighting))
+			}
+		}
+	} else {
+		s, ok := maybeSighting.(*[]*Sighting)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeSighting)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeSighting))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &sightingR{}
+		}
+		args = append(args, object.ProbeID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &sightingR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ProbeID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ProbeID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`probes`),
+		qm.WhereIn(`probes.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Probe")
+	}
+
+	var resultSlice []*Probe
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Probe")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for probes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for probes")
+	}
+
+	if len(sightingAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Probe = foreign
+		if foreign.R == nil {
+			foreign.R = &probeR{}
+		}
+		foreign.R.Sightings = append(foreign.R.Sightings, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ProbeID == foreign.ID {
+				local.R.Probe = foreign
+				if foreign.R == nil {
+					foreign.R = &probeR{}
+				}
+				foreign.R.Sightings = append(foreign.R.Sightings, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetPeer of the sighting to the related item.
+// Sets o.R.Peer to related.
+// Adds o to related.R.Sightings.
+func (o *Sighting) SetPeer(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Peer) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"sightings\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"peer_id"}),
+		strmangle.WhereClause("\"", "\"", 2, sightingPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PeerID = related.ID
+	if o.R == nil {
+		o.R = &sightingR{
+			Peer: related,
+		}
+	} else {
+		o.R.Peer = related
+	}
+
+	if related.R == nil {
+		related.R = &peerR{
+			Sightings: SightingSlice{o},
+		}
+	} else {
+		related.R.Sightings = append(related.R.Sightings, o)
+	}
+
+	return nil
+}
+
+// SetProbe of the sighting to the related item.
+// Sets o.R.Probe to related.
+// Adds o to related.R.Sightings.
+func (o *Sighting) SetProbe(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Probe) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"sightings\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"probe_id"}),
+		strmangle.WhereClause("\"", "\"", 2, sightingPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ProbeID = related.ID
+	if o.R == nil {
+		o.R = &sightingR{
+			Probe: related,
+		}
+	} else {
+		o.R.Probe = related
+	}
+
+	if related.R == nil {
+		related.R = &probeR{
+			Sightings: SightingSlice{o},
+		}
+	} else {
+		related.R.Sightings = append(related.R.Sightings, o)
+	}
+
+	return nil
+}
+
+// Sightings retrieves all the records using an executor.
+func Sightings(mods ...qm.QueryMod) sightingQuery {
+	mods = append(mods, qm.From("\"sightings\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"sightings\".*"})
+	}
+
+	return sightingQuery{q}
+}
+
+// FindSighting retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSighting(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*Sighting, error) {
+	sightingObj := &Sighting{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"sightings\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, sightingObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from sightings")
+	}
+
+	if err = sightingObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return sightingObj, err
+	}
+
+	return sightingObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Sighting) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no sightings provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(sightingColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	sightingInsertCacheMut.RLock()
+	cache, cached := sightingInsertCache[key]
+	sightingInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			sightingAllColumns,
+			sightingColumnsWithDefault,
+			sightingColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, sightingGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(sightingType, sightingMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(sightingType, sightingMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"sightings\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"sightings\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into sightings")
+	}
+
+	if !cached {
+		sightingInsertCacheMut.Lock()
+		sightingInsertCache[key] = cache
+		sightingInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Sighting.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Sighting) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	sightingUpdateCacheMut.RLock()
+	cache, cached := sightingUpdateCache[key]
+	sightingUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			sightingAllColumns,
+			sightingPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, sightingGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update sightings, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"sightings\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, sightingPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(sightingType, sightingMapping, append(wl, sightingPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update sightings row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for sightings")
+	}
+
+	if !cached {
+		sightingUpdateCacheMut.Lock()
+		sightingUpdateCache[key] = cache
+		sightingUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q sightingQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for sightings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for sightings")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SightingSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sightingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"sightings\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, sightingPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in sighting slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all sighting")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Sighting) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no sightings provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(sightingColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	sightingUpsertCacheMut.RLock()
+	cache, cached := sightingUpsertCache[key]
+	sightingUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			sightingAllColumns,
+			sightingColumnsWithDefault,
+			sightingColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			sightingAllColumns,
+			sightingPrimaryKeyColumns,
+		)
+
+		insert = strmangle.SetComplement(insert, sightingGeneratedColumns)
+		update = strmangle.SetComplement(update, sightingGeneratedColumns)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert sightings, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(sightingPrimaryKeyColumns))
+			copy(conflict, sightingPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"sightings\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(sightingType, sightingMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(sightingType, sightingMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert sightings")
+	}
+
+	if !cached {
+		sightingUpsertCacheMut.Lock()
+		sightingUpsertCache[key] = cache
+		sightingUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Sighting record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Sighting) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Sighting provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), sightingPrimaryKeyMapping)
+	sql := "DELETE FROM \"sightings\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from sightings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for sightings")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q sightingQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no sightingQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from sightings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for sightings")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SightingSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(sightingBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sightingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"sightings\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, sightingPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from sighting slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for sightings")
+	}
+
+	if len(sightingAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Sighting) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindSighting(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SightingSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SightingSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), sightingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"sightings\".* FROM \"sightings\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, sightingPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in SightingSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SightingExists checks if the Sighting row exists.
+func SightingExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"sightings\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if sightings exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,950 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testSightings(t *testing.T) {
+	t.Parallel()
+
+	query := Sightings()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testSightingsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSightingsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Sightings().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSightingsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SightingSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSightingsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := SightingExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Sighting exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected SightingExists to return true, but got false.")
+	}
+}
+
+func testSightingsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	sightingFound, err := FindSighting(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if sightingFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testSightingsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Sightings().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSightingsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Sightings().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testSightingsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	sightingOne := &Sighting{}
+	sightingTwo := &Sighting{}
+	if err = randomize.Struct(seed, sightingOne, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+	if err = randomize.Struct(seed, sightingTwo, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = sightingOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = sightingTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Sightings().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testSightingsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	sightingOne := &Sighting{}
+	sightingTwo := &Sighting{}
+	if err = randomize.Struct(seed, sightingOne, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+	if err = randomize.Struct(seed, sightingTwo, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = sightingOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = sightingTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func sightingBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func sightingAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Sighting) error {
+	*o = Sighting{}
+	return nil
+}
+
+func testSightingsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &Sighting{}
+	o := &Sighting{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, sightingDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Sighting object: %s", err)
+	}
+
+	AddSightingHook(boil.BeforeInsertHook, sightingBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	sightingBeforeInsertHooks = []SightingHook{}
+
+	AddSightingHook(boil.AfterInsertHook, sightingAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	sightingAfterInsertHooks = []SightingHook{}
+
+	AddSightingHook(boil.AfterSelectHook, sightingAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	sightingAfterSelectHooks = []SightingHook{}
+
+	AddSightingHook(boil.BeforeUpdateHook, sightingBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	sightingBeforeUpdateHooks = []SightingHook{}
+
+	AddSightingHook(boil.AfterUpdateHook, sightingAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	sightingAfterUpdateHooks = []SightingHook{}
+
+	AddSightingHook(boil.BeforeDeleteHook, sightingBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	sightingBeforeDeleteHooks = []SightingHook{}
+
+	AddSightingHook(boil.AfterDeleteHook, sightingAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	sightingAfterDeleteHooks = []SightingHook{}
+
+	AddSightingHook(boil.BeforeUpsertHook, sightingBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	sightingBeforeUpsertHooks = []SightingHook{}
+
+	AddSightingHook(boil.AfterUpsertHook, sightingAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	sightingAfterUpsertHooks = []SightingHook{}
+}
+
+func testSightingsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSightingsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(sightingColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSightingToOnePeerUsingPeer(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local Sighting
+	var foreign Peer
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, peerDBTypes, false, peerColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Peer struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.PeerID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Peer().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := SightingSlice{&local}
+	if err = local.L.LoadPeer(ctx, tx, false, (*[]*Sighting)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Peer == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Peer = nil
+	if err = local.L.LoadPeer(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Peer == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testSightingToOneProbeUsingProbe(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local Sighting
+	var foreign Probe
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, sightingDBTypes, false, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, probeDBTypes, false, probeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Probe struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.ProbeID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Probe().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := SightingSlice{&local}
+	if err = local.L.LoadProbe(ctx, tx, false, (*[]*Sighting)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Probe == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Probe = nil
+	if err = local.L.LoadProbe(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Probe == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testSightingToOneSetOpPeerUsingPeer(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Sighting
+	var b, c Peer
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, sightingDBTypes, false, strmangle.SetComplement(sightingPrimaryKeyColumns, sightingColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, peerDBTypes, false, strmangle.SetComplement(peerPrimaryKeyColumns, peerColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, peerDBTypes, false, strmangle.SetComplement(peerPrimaryKeyColumns, peerColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Peer{&b, &c} {
+		err = a.SetPeer(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Peer != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Sightings[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.PeerID != x.ID {
+			t.Error("foreign key was wrong value", a.PeerID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.PeerID))
+		reflect.Indirect(reflect.ValueOf(&a.PeerID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.PeerID != x.ID {
+			t.Error("foreign key was wrong value", a.PeerID, x.ID)
+		}
+	}
+}
+func testSightingToOneSetOpProbeUsingProbe(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Sighting
+	var b, c Probe
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, sightingDBTypes, false, strmangle.SetComplement(sightingPrimaryKeyColumns, sightingColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, probeDBTypes, false, strmangle.SetComplement(probePrimaryKeyColumns, probeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Probe{&b, &c} {
+		err = a.SetProbe(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Probe != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Sightings[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.ProbeID != x.ID {
+			t.Error("foreign key was wrong value", a.ProbeID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ProbeID))
+		reflect.Indirect(reflect.ValueOf(&a.ProbeID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ProbeID != x.ID {
+			t.Error("foreign key was wrong value", a.ProbeID, x.ID)
+		}
+	}
+}
+
+func testSightingsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSightingsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SightingSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSightingsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Sightings().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	sightingDBTypes = map[string]string{`ID`: `bigint`, `ProbeID`: `bigint`, `PeerID`: `bigint`, `AgentVersion`: `text`, `Protocols`: `ARRAYtext`, `MultiAddresses`: `ARRAYtext`, `IPAddresses`: `ARRAYtext`, `Countries`: `ARRAYtext`, `Continents`: `ARRAYtext`, `Asns`: `ARRAYinteger`, `SeenAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `WantType`: `enum.want_type('block','have')`, `WantPriority`: `integer`, `SendDontHave`: `boolean`, `CanceledAt`: `timestamp with time zone`, `BlockSentAt`: `timestamp with time zone`, `Transport`: `text`, `IPFamily`: `enum.ip_family('ipv4','ipv6')`, `Identified`: `boolean`, `Direction`: `enum.conn_direction('inbound','outbound')`, `SecurityProtocol`: `text`, `Muxer`: `text`, `ConnOpenedAt`: `timestamp with time zone`, `Relayed`: `boolean`, `PingRTT`: `interval`}
+	_               = bytes.MinRead
+)
+
+func testSightingsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(sightingPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(sightingAllColumns) == len(sightingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testSightingsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(sightingAllColumns) == len(sightingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Sighting{}
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, sightingDBTypes, true, sightingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(sightingAllColumns, sightingPrimaryKeyColumns) {
+		fields = sightingAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			sightingAllColumns,
+			sightingPrimaryKeyColumns,
+		)
+		fields = strmangle.SetComplement(fields, sightingGeneratedColumns)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := SightingSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testSightingsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(sightingAllColumns) == len(sightingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Sighting{}
+	if err = randomize.Struct(seed, &o, sightingDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Sighting: %s", err)
+	}
+
+	count, err := Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, sightingDBTypes, false, sightingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Sighting struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Sighting: %s", err)
+	}
+
+	count, err = Sightings().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
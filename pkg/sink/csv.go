@@ -0,0 +1,274 @@
+package sink
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// The names of the files that the CSV sink writes to.
+const (
+	CSVProbesFile    = "probes.csv"
+	CSVSightingsFile = "sightings.csv"
+	CSVLookupsFile   = "lookups.csv"
+)
+
+var csvProbeHeader = []string{
+	"cid", "target_type", "target_name", "started_at", "ended_at", "outcome", "verification", "error",
+	"provide_duration_ms", "operation_duration_ms", "first_want_latency_ms",
+	"http_status_code", "http_url", "http_redirects", "http_headers", "http_tls_version", "http_bytes",
+}
+
+var csvSightingHeader = []string{
+	"cid", "target_type", "target_name", "peer_id", "agent_version", "protocols", "multi_addresses",
+	"ip_addresses", "countries", "continents", "asns", "seen_at", "transport", "ip_family", "identified", "direction",
+	"security_protocol", "muxer", "conn_opened_at", "relayed", "ping_rtt_ms", "want_type", "want_priority",
+	"send_dont_have", "canceled_at", "block_sent_at",
+}
+
+var csvLookupHeader = []string{
+	"cid", "target_type", "target_name", "peer_id", "agent_version", "multi_addresses", "lookup_type", "seen_at",
+}
+
+// CSV writes finished probes, sightings and lookups to a probes.csv, sightings.csv and lookups.csv file in
+// a directory. List values are joined by a semicolon. Sightings and lookups reference their probe by the cid column.
+type CSV struct {
+	mu        sync.Mutex
+	probes    *csvFile
+	sightings *csvFile
+	lookups   *csvFile
+}
+
+var _ Sink = (*CSV)(nil)
+
+type csvFile struct {
+	f *os.File
+	w *csv.Writer
+}
+
+// NewCSV creates a sink that appends to the CSV files in the given directory. The directory
+// and files are created if they don't exist.
+func NewCSV(dir string) (*CSV, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create csv directory")
+	}
+
+	probes, err := openCSVFile(filepath.Join(dir, CSVProbesFile), csvProbeHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	sightings, err := openCSVFile(filepath.Join(dir, CSVSightingsFile), csvSightingHeader)
+	if err != nil {
+		_ = probes.f.Close()
+		return nil, err
+	}
+
+	lookups, err := openCSVFile(filepath.Join(dir, CSVLookupsFile), csvLookupHeader)
+	if err != nil {
+		_ = probes.f.Close()
+		_ = sightings.f.Close()
+		return nil, err
+	}
+
+	return &CSV{probes: probes, sightings: sightings, lookups: lookups}, nil
+}
+
+// openCSVFile opens the given file for appending and writes the header if the file is empty.
+func openCSVFile(path string, header []string) (*csvFile, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open %s", path)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.Wrapf(err, "stat %s", path)
+	}
+
+	cf := &csvFile{f: f, w: csv.NewWriter(f)}
+	if fi.Size() == 0 {
+		if err = cf.write(header); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+
+	return cf, nil
+}
+
+func (cf *csvFile) write(record []string) error {
+	if err := cf.w.Write(record); err != nil {
+		return errors.Wrap(err, "write csv record")
+	}
+	cf.w.Flush()
+	return errors.Wrap(cf.w.Error(), "flush csv writer")
+}
+
+func (c *CSV) StartProbe(ctx context.Context, p *Probe) error {
+	return nil
+}
+
+func (c *CSV) TrackSighting(ctx context.Context, s *Sighting) error {
+	return nil
+}
+
+func (c *CSV) FinishSighting(ctx context.Context, s *Sighting) error {
+	asns := make([]string, len(s.ASNs))
+	for i, asn := range s.ASNs {
+		asns[i] = strconv.FormatInt(asn, 10)
+	}
+
+	record := []string{
+		s.Probe.CID,
+		s.Probe.TargetType,
+		s.Probe.TargetName,
+		s.PeerID,
+		s.AgentVersion,
+		strings.Join(s.Protocols, ";"),
+		strings.Join(s.MultiAddresses, ";"),
+		strings.Join(s.IPAddresses, ";"),
+		strings.Join(s.Countries, ";"),
+		strings.Join(s.Continents, ";"),
+		strings.Join(asns, ";"),
+		csvTime(s.SeenAt),
+		s.Transport,
+		s.IPFamily,
+		strconv.FormatBool(s.Identified),
+		s.Direction,
+		s.SecurityProtocol,
+		s.Muxer,
+		csvConnTime(s.ConnOpenedAt),
+		csvConnBool(s.Relayed, s.ConnOpenedAt),
+		csvMillis(s.PingRTT),
+	}
+
+	if s.Want != nil {
+		record = append(record,
+			s.Want.Type,
+			strconv.Itoa(int(s.Want.Priority)),
+			strconv.FormatBool(s.Want.SendDontHave),
+			csvTime(s.Want.CanceledAt),
+			csvTime(s.Want.BlockSentAt),
+		)
+	} else {
+		record = append(record, "", "", "", "", "")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sightings.write(record)
+}
+
+func (c *CSV) TrackLookup(ctx context.Context, l *Lookup) error {
+	record := []string{
+		l.Probe.CID,
+		l.Probe.TargetType,
+		l.Probe.TargetName,
+		l.PeerID,
+		l.AgentVersion,
+		strings.Join(l.MultiAddresses, ";"),
+		l.Type,
+		csvTime(l.SeenAt),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lookups.write(record)
+}
+
+func (c *CSV) FinishProbe(ctx context.Context, p *Probe) error {
+	record := []string{
+		p.CID,
+		p.TargetType,
+		p.TargetName,
+		csvTime(p.StartedAt),
+		csvTime(p.EndedAt),
+		p.Outcome,
+		p.Verification,
+		p.Error,
+		csvMillis(p.ProvideDuration),
+		csvMillis(p.OperationDuration),
+		csvMillis(p.FirstWantLatency),
+	}
+
+	// The HTTP columns are left empty if the target wasn't requested via HTTP.
+	httpRecord := make([]string, 6)
+	if r := p.HTTPResponse; r != nil {
+		httpRecord[0] = strconv.Itoa(r.StatusCode)
+		httpRecord[1] = r.URL
+		httpRecord[2] = strings.Join(r.Redirects, ";")
+		if len(r.Headers) > 0 {
+			data, err := json.Marshal(r.Headers)
+			if err != nil {
+				return errors.Wrap(err, "marshal http headers")
+			}
+			httpRecord[3] = string(data)
+		}
+		httpRecord[4] = r.TLSVersion
+		httpRecord[5] = strconv.FormatInt(r.Bytes, 10)
+	}
+	record = append(record, httpRecord...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.probes.write(record)
+}
+
+func (c *CSV) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return multierr.Combine(c.probes.f.Close(), c.sightings.f.Close(), c.lookups.f.Close())
+}
+
+// csvTime formats the given time as RFC 3339. A zero time is mapped to an empty string.
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// csvConnTime formats the time at which a connection was opened. It's mapped to an empty string if there
+// was no connection.
+func csvConnTime(openedAt *time.Time) string {
+	if openedAt == nil {
+		return ""
+	}
+	return csvTime(*openedAt)
+}
+
+// csvConnBool formats a boolean property of the connection that was opened at the given time. It's
+// mapped to an empty string if there was no connection.
+func csvConnBool(b bool, openedAt *time.Time) string {
+	if openedAt == nil {
+		return ""
+	}
+	return strconv.FormatBool(b)
+}
+
+// csvMillis formats the given duration as fractional milliseconds. A zero duration is mapped to an empty string.
+func csvMillis(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+}
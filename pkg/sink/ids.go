@@ -0,0 +1,82 @@
+package sink
+
+import (
+	"fmt"
+	"sync"
+)
+
+// rowIDs keeps track of the database row IDs that a sink assigned to probes and sightings. The same
+// probes and sightings are passed to all configured sinks, so every database sink keeps its own IDs.
+type rowIDs struct {
+	mu        sync.Mutex
+	probes    map[*Probe]int64
+	sightings map[*Sighting]int64
+}
+
+func newRowIDs() *rowIDs {
+	return &rowIDs{
+		probes:    map[*Probe]int64{},
+		sightings: map[*Sighting]int64{},
+	}
+}
+
+// setProbe records the row ID of the given probe.
+func (r *rowIDs) setProbe(p *Probe, id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.probes[p] = id
+}
+
+// probe returns the row ID of the given probe. It returns an error if the probe wasn't started in this sink.
+func (r *rowIDs) probe(p *Probe) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, found := r.probes[p]
+	if !found {
+		return 0, fmt.Errorf("probe of %s for cid %s wasn't started", p.TargetName, p.CID)
+	}
+	return id, nil
+}
+
+// setSighting records the row ID of the given sighting.
+func (r *rowIDs) setSighting(s *Sighting, id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sightings[s] = id
+}
+
+// sighting returns the row ID of the given sighting. It returns an error if the sighting wasn't tracked in this sink.
+func (r *rowIDs) sighting(s *Sighting) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, found := r.sightings[s]
+	if !found {
+		return 0, fmt.Errorf("sighting of peer %s wasn't tracked", s.PeerID)
+	}
+	return id, nil
+}
+
+// forgetSighting removes the row ID of the given sighting after it was finished.
+func (r *rowIDs) forgetSighting(s *Sighting) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sightings, s)
+}
+
+// forgetProbe removes the row IDs of the given probe and of all its sightings after it was finished.
+func (r *rowIDs) forgetProbe(p *Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.probes, p)
+	for s := range r.sightings {
+		if s.Probe == p {
+			delete(r.sightings, s)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// JSONL writes finished probes, sightings and lookups as newline-delimited JSON objects. Each
+// object has a "type" field that is either "probe", "sighting" or "lookup". Sightings and lookups
+// reference their probe by the "cid" field.
+type JSONL struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+}
+
+var _ Sink = (*JSONL)(nil)
+
+// NewJSONL creates a sink that appends to the file at the given path. If the path is
+// empty or "-", the results are written to stdout.
+func NewJSONL(path string) (*JSONL, error) {
+	w := io.Writer(os.Stdout)
+	if path != "" && path != "-" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, errors.Wrap(err, "open jsonl file")
+		}
+		w = f
+	}
+
+	return &JSONL{w: w, enc: json.NewEncoder(w)}, nil
+}
+
+type jsonlProbe struct {
+	Type string `json:"type"`
+	*Probe
+}
+
+type jsonlSighting struct {
+	Type       string `json:"type"`
+	CID        string `json:"cid"`
+	TargetType string `json:"target_type"`
+	TargetName string `json:"target_name"`
+	*Sighting
+}
+
+type jsonlLookup struct {
+	Type       string `json:"type"`
+	CID        string `json:"cid"`
+	TargetType string `json:"target_type"`
+	TargetName string `json:"target_name"`
+	*Lookup
+}
+
+func (j *JSONL) StartProbe(ctx context.Context, p *Probe) error {
+	return nil
+}
+
+func (j *JSONL) TrackSighting(ctx context.Context, s *Sighting) error {
+	return nil
+}
+
+func (j *JSONL) FinishSighting(ctx context.Context, s *Sighting) error {
+	return j.encode(&jsonlSighting{
+		Type:       "sighting",
+		CID:        s.Probe.CID,
+		TargetType: s.Probe.TargetType,
+		TargetName: s.Probe.TargetName,
+		Sighting:   s,
+	})
+}
+
+func (j *JSONL) TrackLookup(ctx context.Context, l *Lookup) error {
+	return j.encode(&jsonlLookup{
+		Type:       "lookup",
+		CID:        l.Probe.CID,
+		TargetType: l.Probe.TargetType,
+		TargetName: l.Probe.TargetName,
+		Lookup:     l,
+	})
+}
+
+func (j *JSONL) FinishProbe(ctx context.Context, p *Probe) error {
+	return j.encode(&jsonlProbe{Type: "probe", Probe: p})
+}
+
+func (j *JSONL) encode(v any) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return errors.Wrap(j.enc.Encode(v), "encode json")
+}
+
+// Close closes the underlying file. Stdout is left open.
+func (j *JSONL) Close() error {
+	if f, ok := j.w.(*os.File); ok && f != os.Stdout {
+		return f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Log writes the results to the log. This is the default in dry-run mode.
+type Log struct{}
+
+var _ Sink = (*Log)(nil)
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) StartProbe(ctx context.Context, p *Probe) error {
+	return nil
+}
+
+func (l *Log) TrackSighting(ctx context.Context, s *Sighting) error {
+	logEntry := log.WithField("type", s.Probe.TargetType).WithField("name", s.Probe.TargetName).WithField("cid", s.Probe.CID)
+
+	logEntry.Infoln("Tracked the following peer:")
+	logEntry.Infoln("  PeerID", s.PeerID)
+	logEntry.Infoln("  AgentVersion", s.AgentVersion)
+	logEntry.Infoln("  Protocols", s.Protocols)
+	for i, protocol := range s.Protocols {
+		logEntry.Infof("    [%d] %s\n", i, protocol)
+	}
+	logEntry.Infoln("  MultiAddresses", s.MultiAddresses)
+	for i, maddrStr := range s.MultiAddresses {
+		logEntry.Infof("    [%d] %s\n", i, maddrStr)
+	}
+	logEntry.Infoln("  IPAddresses", s.IPAddresses)
+	for i, ipAddress := range s.IPAddresses {
+		logEntry.Infof("    [%d] %s\n", i, ipAddress)
+	}
+	logEntry.Infoln("  Countries", s.Countries)
+	logEntry.Infoln("  Continents", s.Continents)
+	logEntry.Infoln("  ASNs", s.ASNs)
+	logEntry.Infoln("  TargetType", s.Probe.TargetType)
+	logEntry.Infoln("  TargetName", s.Probe.TargetName)
+	logEntry.Infoln("  SeenAt", s.SeenAt)
+	logEntry.Infoln("  Transport", s.Transport)
+	logEntry.Infoln("  IPFamily", s.IPFamily)
+	logEntry.Infoln("  Identified", s.Identified)
+	logEntry.Infoln("  Direction", s.Direction)
+	logEntry.Infoln("  SecurityProtocol", s.SecurityProtocol)
+	logEntry.Infoln("  Muxer", s.Muxer)
+	logEntry.Infoln("  ConnOpenedAt", s.ConnOpenedAt)
+	logEntry.Infoln("  Relayed", s.Relayed)
+	logEntry.Infoln("  PingRTT", s.PingRTT)
+	if s.Want != nil {
+		logEntry.Infoln("  WantType", s.Want.Type)
+		logEntry.Infoln("  WantPriority", s.Want.Priority)
+		logEntry.Infoln("  SendDontHave", s.Want.SendDontHave)
+	}
+
+	return nil
+}
+
+func (l *Log) FinishSighting(ctx context.Context, s *Sighting) error {
+	if s.Want == nil {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"type":        s.Probe.TargetType,
+		"name":        s.Probe.TargetName,
+		"cid":         s.Probe.CID,
+		"peerID":      s.PeerID,
+		"canceledAt":  s.Want.CanceledAt,
+		"blockSentAt": s.Want.BlockSentAt,
+	}).Infoln("Finished sighting")
+
+	return nil
+}
+
+func (l *Log) TrackLookup(ctx context.Context, lookup *Lookup) error {
+	log.WithFields(log.Fields{
+		"type":       lookup.Probe.TargetType,
+		"name":       lookup.Probe.TargetName,
+		"cid":        lookup.Probe.CID,
+		"peerID":     lookup.PeerID,
+		"agent":      lookup.AgentVersion,
+		"lookupType": lookup.Type,
+	}).Infoln("Tracked DHT lookup")
+
+	return nil
+}
+
+func (l *Log) FinishProbe(ctx context.Context, p *Probe) error {
+	return nil
+}
+
+func (l *Log) Close() error {
+	return nil
+}
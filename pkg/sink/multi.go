@@ -0,0 +1,72 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/multierr"
+)
+
+// multi forwards all results to multiple sinks.
+type multi struct {
+	sinks []Sink
+}
+
+var _ Sink = (*multi)(nil)
+
+// Multi returns a sink that forwards all results to the given sinks. A failing sink
+// doesn't prevent the results from being written to the others. Only if all sinks
+// fail, an error is returned.
+func Multi(sinks ...Sink) Sink {
+	return &multi{sinks: sinks}
+}
+
+func (m *multi) StartProbe(ctx context.Context, p *Probe) error {
+	return m.each(func(s Sink) error { return s.StartProbe(ctx, p) })
+}
+
+func (m *multi) TrackSighting(ctx context.Context, s *Sighting) error {
+	return m.each(func(snk Sink) error { return snk.TrackSighting(ctx, s) })
+}
+
+func (m *multi) FinishSighting(ctx context.Context, s *Sighting) error {
+	return m.each(func(snk Sink) error { return snk.FinishSighting(ctx, s) })
+}
+
+func (m *multi) TrackLookup(ctx context.Context, l *Lookup) error {
+	return m.each(func(s Sink) error { return s.TrackLookup(ctx, l) })
+}
+
+func (m *multi) FinishProbe(ctx context.Context, p *Probe) error {
+	return m.each(func(s Sink) error { return s.FinishProbe(ctx, p) })
+}
+
+// Close closes all sinks and returns the errors of all sinks that couldn't be closed.
+func (m *multi) Close() error {
+	var err error
+	for _, s := range m.sinks {
+		err = multierr.Append(err, s.Close())
+	}
+	return err
+}
+
+// each calls the given function for every sink. The errors of individual sinks are only logged,
+// and the combined error is returned if the function failed for all sinks.
+func (m *multi) each(fn func(s Sink) error) error {
+	var errs error
+	failed := 0
+	for _, s := range m.sinks {
+		if err := fn(s); err != nil {
+			log.WithError(err).WithField("sink", fmt.Sprintf("%T", s)).Warnln("Error writing results to sink")
+			errs = multierr.Append(errs, err)
+			failed += 1
+		}
+	}
+
+	if failed > 0 && failed == len(m.sinks) {
+		return errs
+	}
+
+	return nil
+}
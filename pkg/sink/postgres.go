@@ -0,0 +1,265 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/dennis-tra/antares/pkg/db"
+	"github.com/dennis-tra/antares/pkg/models"
+)
+
+// Postgres writes the results to the `probes`, `sightings` and `peers` tables.
+type Postgres struct {
+	dbc *db.Client
+	ids *rowIDs
+}
+
+var _ Sink = (*Postgres)(nil)
+
+func NewPostgres(dbc *db.Client) *Postgres {
+	return &Postgres{dbc: dbc, ids: newRowIDs()}
+}
+
+// StartProbe inserts a new probe run into the database and remembers its ID.
+func (p *Postgres) StartProbe(ctx context.Context, probe *Probe) error {
+	dbProbe := &models.Probe{
+		TargetType: probe.TargetType,
+		TargetName: probe.TargetName,
+		Cid:        probe.CID,
+		StartedAt:  probe.StartedAt,
+	}
+	if err := dbProbe.Insert(ctx, p.dbc, boil.Infer()); err != nil {
+		return errors.Wrap(err, "insert db probe")
+	}
+	p.ids.setProbe(probe, dbProbe.ID)
+
+	return nil
+}
+
+// postgresPeerUpsert inserts a new peer or updates the latest state of an existing one in a single statement, so that
+// concurrent sightings of the same peer don't race for the unique constraint. Empty values don't overwrite
+// previously known ones.
+const postgresPeerUpsert = `
+INSERT INTO peers (multi_hash, agent_version, protocols, multi_addresses, ip_addresses, countries, continents, asns,
+                   target_type, target_name, last_seen_at, updated_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+ON CONFLICT (multi_hash, target_name) DO UPDATE SET
+    agent_version   = COALESCE(EXCLUDED.agent_version, peers.agent_version),
+    protocols       = CASE WHEN COALESCE(cardinality(EXCLUDED.protocols), 0) = 0 THEN peers.protocols ELSE EXCLUDED.protocols END,
+    multi_addresses = CASE WHEN cardinality(EXCLUDED.multi_addresses) = 0 THEN peers.multi_addresses ELSE EXCLUDED.multi_addresses END,
+    ip_addresses    = CASE WHEN cardinality(EXCLUDED.ip_addresses) = 0 THEN peers.ip_addresses ELSE EXCLUDED.ip_addresses END,
+    countries       = CASE WHEN cardinality(EXCLUDED.countries) = 0 THEN peers.countries ELSE EXCLUDED.countries END,
+    continents      = CASE WHEN cardinality(EXCLUDED.continents) = 0 THEN peers.continents ELSE EXCLUDED.continents END,
+    asns            = CASE WHEN cardinality(EXCLUDED.asns) = 0 THEN peers.asns ELSE EXCLUDED.asns END,
+    last_seen_at    = EXCLUDED.last_seen_at,
+    updated_at      = EXCLUDED.updated_at
+RETURNING id`
+
+// TrackSighting updates the latest state of the given peer in the `peers` table and appends a new sighting
+// of that peer to the `sightings` table.
+func (p *Postgres) TrackSighting(ctx context.Context, s *Sighting) error {
+	probeID, err := p.ids.probe(s.Probe)
+	if err != nil {
+		return err
+	}
+
+	txn, err := p.dbc.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin txn")
+	}
+	defer func() {
+		if err = txn.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.WithError(err).Warnln("Error rolling back transaction")
+		}
+	}()
+
+	var peerID int64
+	err = txn.QueryRowContext(ctx, postgresPeerUpsert, s.PeerID, null.NewString(s.AgentVersion, s.AgentVersion != ""),
+		types.StringArray(s.Protocols), pgStringArray(s.MultiAddresses), pgStringArray(s.IPAddresses),
+		pgStringArray(s.Countries), pgStringArray(s.Continents), pgInt64Array(s.ASNs), s.Probe.TargetType,
+		s.Probe.TargetName, s.SeenAt).Scan(&peerID)
+	if err != nil {
+		return errors.Wrap(err, "upsert db peer")
+	}
+
+	dbSighting := &models.Sighting{
+		ProbeID:        probeID,
+		PeerID:         peerID,
+		AgentVersion:   null.NewString(s.AgentVersion, s.AgentVersion != ""),
+		Protocols:      s.Protocols,
+		MultiAddresses: pgStringArray(s.MultiAddresses),
+		IPAddresses:    pgStringArray(s.IPAddresses),
+		Countries:      pgStringArray(s.Countries),
+		Continents:     pgStringArray(s.Continents),
+		Asns:           pgInt64Array(s.ASNs),
+		SeenAt:         s.SeenAt,
+		Transport:      null.NewString(s.Transport, s.Transport != ""),
+		IPFamily:       null.NewString(s.IPFamily, s.IPFamily != ""),
+		Identified:     null.BoolFrom(s.Identified),
+		PingRTT:        nullInterval(s.PingRTT),
+	}
+	if s.ConnOpenedAt != nil {
+		dbSighting.Direction = null.NewString(s.Direction, s.Direction != "")
+		dbSighting.SecurityProtocol = null.NewString(s.SecurityProtocol, s.SecurityProtocol != "")
+		dbSighting.Muxer = null.NewString(s.Muxer, s.Muxer != "")
+		dbSighting.ConnOpenedAt = null.TimeFrom(*s.ConnOpenedAt)
+		dbSighting.Relayed = null.BoolFrom(s.Relayed)
+	}
+	if s.Want != nil {
+		dbSighting.WantType = null.StringFrom(s.Want.Type)
+		dbSighting.WantPriority = null.IntFrom(int(s.Want.Priority))
+		dbSighting.SendDontHave = null.BoolFrom(s.Want.SendDontHave)
+	}
+	if err = dbSighting.Insert(ctx, txn, boil.Infer()); err != nil {
+		return errors.Wrap(err, "insert db sighting")
+	}
+
+	if err = txn.Commit(); err != nil {
+		return errors.Wrap(err, "commit txn")
+	}
+	p.ids.setSighting(s, dbSighting.ID)
+
+	return nil
+}
+
+// FinishSighting persists the Bitswap interactions that happened after the sighting was tracked.
+func (p *Postgres) FinishSighting(ctx context.Context, s *Sighting) error {
+	defer p.ids.forgetSighting(s)
+
+	if s.Want == nil || (s.Want.CanceledAt.IsZero() && s.Want.BlockSentAt.IsZero()) {
+		return nil
+	}
+
+	id, err := p.ids.sighting(s)
+	if err != nil {
+		return err
+	}
+
+	dbSighting := &models.Sighting{
+		ID:          id,
+		CanceledAt:  null.NewTime(s.Want.CanceledAt, !s.Want.CanceledAt.IsZero()),
+		BlockSentAt: null.NewTime(s.Want.BlockSentAt, !s.Want.BlockSentAt.IsZero()),
+	}
+
+	cols := boil.Whitelist(models.SightingColumns.CanceledAt, models.SightingColumns.BlockSentAt)
+	if _, err = dbSighting.Update(ctx, p.dbc, cols); err != nil {
+		return errors.Wrap(err, "update db sighting")
+	}
+
+	return nil
+}
+
+// TrackLookup appends the given lookup to the `lookups` table.
+func (p *Postgres) TrackLookup(ctx context.Context, l *Lookup) error {
+	probeID, err := p.ids.probe(l.Probe)
+	if err != nil {
+		return err
+	}
+
+	dbLookup := &models.Lookup{
+		ProbeID:        probeID,
+		MultiHash:      l.PeerID,
+		AgentVersion:   null.NewString(l.AgentVersion, l.AgentVersion != ""),
+		MultiAddresses: pgStringArray(l.MultiAddresses),
+		Type:           l.Type,
+		SeenAt:         l.SeenAt,
+	}
+	if err = dbLookup.Insert(ctx, p.dbc, boil.Infer()); err != nil {
+		return errors.Wrap(err, "insert db lookup")
+	}
+
+	return nil
+}
+
+// FinishProbe persists the end, outcome and timings of the given probe.
+func (p *Postgres) FinishProbe(ctx context.Context, probe *Probe) error {
+	defer p.ids.forgetProbe(probe)
+
+	id, err := p.ids.probe(probe)
+	if err != nil {
+		return err
+	}
+
+	dbProbe := &models.Probe{
+		ID:                id,
+		Outcome:           null.StringFrom(probe.Outcome),
+		Verification:      null.NewString(probe.Verification, probe.Verification != ""),
+		Error:             null.NewString(probe.Error, probe.Error != ""),
+		EndedAt:           null.TimeFrom(probe.EndedAt),
+		ProvideDuration:   nullInterval(probe.ProvideDuration),
+		OperationDuration: nullInterval(probe.OperationDuration),
+		FirstWantLatency:  nullInterval(probe.FirstWantLatency),
+	}
+
+	if r := probe.HTTPResponse; r != nil {
+		dbProbe.HTTPStatusCode = null.IntFrom(r.StatusCode)
+		dbProbe.HTTPURL = null.StringFrom(r.URL)
+		dbProbe.HTTPRedirects = r.Redirects
+		if len(r.Headers) > 0 {
+			headers, err := json.Marshal(r.Headers)
+			if err != nil {
+				return errors.Wrap(err, "marshal http headers")
+			}
+			dbProbe.HTTPHeaders = null.JSONFrom(headers)
+		}
+		dbProbe.HTTPTLSVersion = null.NewString(r.TLSVersion, r.TLSVersion != "")
+		dbProbe.HTTPBytes = null.Int64From(r.Bytes)
+	}
+
+	cols := boil.Whitelist(
+		models.ProbeColumns.Outcome,
+		models.ProbeColumns.Verification,
+		models.ProbeColumns.Error,
+		models.ProbeColumns.EndedAt,
+		models.ProbeColumns.ProvideDuration,
+		models.ProbeColumns.OperationDuration,
+		models.ProbeColumns.FirstWantLatency,
+		models.ProbeColumns.HTTPStatusCode,
+		models.ProbeColumns.HTTPURL,
+		models.ProbeColumns.HTTPRedirects,
+		models.ProbeColumns.HTTPHeaders,
+		models.ProbeColumns.HTTPTLSVersion,
+		models.ProbeColumns.HTTPBytes,
+	)
+	if _, err := dbProbe.Update(ctx, p.dbc, cols); err != nil {
+		return errors.Wrap(err, "update db probe")
+	}
+
+	return nil
+}
+
+// Close is a no-op, because the database client is owned by the caller.
+func (p *Postgres) Close() error {
+	return nil
+}
+
+// nullInterval converts the given duration to a postgres interval. A zero duration is mapped to NULL.
+func nullInterval(d time.Duration) null.String {
+	return null.NewString(fmt.Sprintf("%f seconds", d.Seconds()), d != 0)
+}
+
+// pgStringArray returns an empty instead of a NULL array for the given slice,
+// because most array columns of the `peers`, `sightings` and `lookups` tables are not nullable.
+func pgStringArray(a []string) types.StringArray {
+	if a == nil {
+		return types.StringArray{}
+	}
+	return a
+}
+
+// pgInt64Array is the pgStringArray equivalent for integer arrays.
+func pgInt64Array(a []int64) types.Int64Array {
+	if a == nil {
+		return types.Int64Array{}
+	}
+	return a
+}
@@ -0,0 +1,108 @@
+package sink
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/db"
+)
+
+// testPostgres connects to the database that is configured by the ANTARES_TEST_DATABASE_* environment variables
+// and applies all migrations. The test is skipped if no database host is configured.
+func testPostgres(t *testing.T) *db.Client {
+	host := os.Getenv("ANTARES_TEST_DATABASE_HOST")
+	if host == "" {
+		t.Skip("ANTARES_TEST_DATABASE_HOST not set")
+	}
+
+	conf := config.DefaultConfig
+	conf.Database.Host = host
+	if port := os.Getenv("ANTARES_TEST_DATABASE_PORT"); port != "" {
+		p, err := strconv.Atoi(port)
+		require.NoError(t, err)
+		conf.Database.Port = p
+	}
+	if name := os.Getenv("ANTARES_TEST_DATABASE_NAME"); name != "" {
+		conf.Database.Name = name
+	}
+	if user := os.Getenv("ANTARES_TEST_DATABASE_USER"); user != "" {
+		conf.Database.User = user
+	}
+	if password := os.Getenv("ANTARES_TEST_DATABASE_PASSWORD"); password != "" {
+		conf.Database.Password = password
+	}
+
+	dbc, err := db.InitClient(&conf)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dbc.Close() })
+
+	require.NoError(t, dbc.MigrateUp(context.Background()))
+
+	return dbc
+}
+
+func TestPostgres_TrackSighting_parallel(t *testing.T) {
+	ctx := context.Background()
+	dbc := testPostgres(t)
+	p := NewPostgres(dbc)
+
+	probe, sighting := testResults()
+	probe.TargetName = t.Name()
+	require.NoError(t, p.StartProbe(ctx, probe))
+	probeID, err := p.ids.probe(probe)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		s := *sighting
+		s.Want = nil
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- p.TrackSighting(ctx, &s)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var peerCount, sightingCount int
+	err = dbc.QueryRowContext(ctx, `SELECT count(*) FROM peers WHERE multi_hash = $1 AND target_name = $2`, sighting.PeerID, probe.TargetName).Scan(&peerCount)
+	require.NoError(t, err)
+	assert.Equal(t, 1, peerCount)
+
+	err = dbc.QueryRowContext(ctx, `SELECT count(*) FROM sightings WHERE probe_id = $1`, probeID).Scan(&sightingCount)
+	require.NoError(t, err)
+	assert.Equal(t, 10, sightingCount)
+}
+
+func TestPostgres_TrackSighting_noAddresses(t *testing.T) {
+	ctx := context.Background()
+	p := NewPostgres(testPostgres(t))
+
+	probe, _ := testResults()
+	probe.TargetName = t.Name()
+	require.NoError(t, p.StartProbe(ctx, probe))
+
+	// A peer without resolved addresses or Maxmind match must be tracked nonetheless
+	sighting := &Sighting{Probe: probe, PeerID: "12D3KooWnoaddrs", SeenAt: probe.StartedAt}
+	require.NoError(t, p.TrackSighting(ctx, sighting))
+	require.NoError(t, p.TrackLookup(ctx, &Lookup{Probe: probe, PeerID: "12D3KooWnoaddrs", Type: "find_node", SeenAt: probe.StartedAt}))
+}
+
+func TestPgArray(t *testing.T) {
+	assert.NotNil(t, pgStringArray(nil))
+	assert.NotNil(t, pgInt64Array(nil))
+	assert.Equal(t, []string{"DE"}, []string(pgStringArray([]string{"DE"})))
+}
@@ -0,0 +1,198 @@
+// Package sink defines where the results of the probes are written to. Besides the
+// Postgres database, results can be written to an embedded SQLite database, as
+// newline-delimited JSON or CSV files, or just be logged.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/db"
+)
+
+// The types of sinks that can be configured.
+const (
+	TypePostgres = "postgres"
+	TypeSQLite   = "sqlite"
+	TypeJSONL    = "jsonl"
+	TypeCSV      = "csv"
+	TypeLog      = "log"
+)
+
+// A Sink receives the results of the probes. The methods are called in the order of the
+// lifecycle of a probe: StartProbe, then TrackSighting and FinishSighting for every peer that
+// was observed during the probe and TrackLookup for every peer that looked up the probe CID,
+// and finally FinishProbe. Implementations must be safe for concurrent use, because all probes
+// share the same sink.
+type Sink interface {
+	// StartProbe records that the given probe has started.
+	StartProbe(ctx context.Context, p *Probe) error
+
+	// TrackSighting records that a peer was observed during a probe. The sighting may be
+	// complemented with further details until FinishSighting is called.
+	TrackSighting(ctx context.Context, s *Sighting) error
+
+	// FinishSighting records the final state of the given sighting.
+	FinishSighting(ctx context.Context, s *Sighting) error
+
+	// TrackLookup records that a peer looked up the CID of a probe in the DHT.
+	TrackLookup(ctx context.Context, l *Lookup) error
+
+	// FinishProbe records the final state of the given probe.
+	FinishProbe(ctx context.Context, p *Probe) error
+
+	// Close flushes and releases all resources of the sink.
+	Close() error
+}
+
+// Probe is a single probe run of a target.
+type Probe struct {
+	TargetType string    `json:"target_type"`
+	TargetName string    `json:"target_name"`
+	CID        string    `json:"cid"`
+	StartedAt  time.Time `json:"started_at"`
+
+	// The following fields are only set when the probe has finished.
+
+	EndedAt           time.Time     `json:"ended_at"`
+	Outcome           string        `json:"outcome"`
+	Verification      string        `json:"verification,omitempty"`
+	Error             string        `json:"error,omitempty"`
+	ProvideDuration   time.Duration `json:"provide_duration_ns,omitempty"`
+	OperationDuration time.Duration `json:"operation_duration_ns,omitempty"`
+	FirstWantLatency  time.Duration `json:"first_want_latency_ns,omitempty"`
+
+	// HTTPResponse is the last response of the target if it was requested via HTTP.
+	HTTPResponse *HTTPResponse `json:"http_response,omitempty"`
+}
+
+// HTTPResponse contains the details of an HTTP response of a target, e.g., a gateway, that reveal
+// its CDN and caching layers.
+type HTTPResponse struct {
+	StatusCode int               `json:"status_code"`
+	URL        string            `json:"url"`
+	Redirects  []string          `json:"redirects,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	TLSVersion string            `json:"tls_version,omitempty"`
+	Bytes      int64             `json:"bytes"`
+}
+
+// Sighting is a peer that was observed during a probe with its attributes at that moment.
+type Sighting struct {
+	// Probe is the probe during which the peer was observed.
+	Probe *Probe `json:"-"`
+
+	PeerID         string    `json:"peer_id"`
+	AgentVersion   string    `json:"agent_version,omitempty"`
+	Protocols      []string  `json:"protocols"`
+	MultiAddresses []string  `json:"multi_addresses"`
+	IPAddresses    []string  `json:"ip_addresses"`
+	Countries      []string  `json:"countries"`
+	Continents     []string  `json:"continents"`
+	ASNs           []int64   `json:"asns"`
+	SeenAt         time.Time `json:"seen_at"`
+
+	// Transport and IPFamily describe the connection on which the peer was observed, e.g., quic-v1
+	// and ipv6. They're empty if Antares wasn't connected to the peer.
+	Transport string `json:"transport,omitempty"`
+	IPFamily  string `json:"ip_family,omitempty"`
+
+	// The further details of the connection on which the peer was observed. Direction is inbound if the peer
+	// dialed us. The Muxer is empty for transports with native stream multiplexing like QUIC. All fields
+	// are zero if Antares wasn't connected to the peer, in which case ConnOpenedAt is nil.
+	Direction        string     `json:"direction,omitempty"`
+	SecurityProtocol string     `json:"security_protocol,omitempty"`
+	Muxer            string     `json:"muxer,omitempty"`
+	ConnOpenedAt     *time.Time `json:"conn_opened_at,omitempty"`
+	Relayed          bool       `json:"relayed"`
+
+	// PingRTT is the round trip time of a libp2p ping to the peer. It's zero if the ping failed.
+	PingRTT time.Duration `json:"ping_rtt_ns,omitempty"`
+
+	// Identified is true if the identify exchange with the peer succeeded before it was recorded. Otherwise,
+	// the AgentVersion and Protocols are missing rather than empty.
+	Identified bool `json:"identified"`
+
+	// Want is the Bitswap want through which the peer was observed. It's nil if the
+	// peer wasn't observed through Bitswap, e.g., if it was found as a provider.
+	Want *Want `json:"want,omitempty"`
+}
+
+// Want contains the details of the Bitswap want through which a peer was observed.
+type Want struct {
+	Type         string    `json:"type"`
+	Priority     int32     `json:"priority"`
+	SendDontHave bool      `json:"send_dont_have"`
+	CanceledAt   time.Time `json:"canceled_at"`
+	BlockSentAt  time.Time `json:"block_sent_at"`
+}
+
+// Lookup is a DHT request for the CID of a probe that we received from a peer.
+type Lookup struct {
+	// Probe is the probe whose CID was looked up.
+	Probe *Probe `json:"-"`
+
+	PeerID         string    `json:"peer_id"`
+	AgentVersion   string    `json:"agent_version,omitempty"`
+	MultiAddresses []string  `json:"multi_addresses"`
+	Type           string    `json:"lookup_type"`
+	SeenAt         time.Time `json:"seen_at"`
+}
+
+// New initializes the sinks from the given configuration. If no sinks are configured, the results
+// are written to Postgres, or only logged if Antares runs in dry-run mode. The database client
+// may be nil in dry-run mode.
+func New(conf *config.Config, dbc *db.Client) (Sink, error) {
+	sinkConfs := conf.Sinks
+	if len(sinkConfs) == 0 {
+		if conf.Database.DryRun {
+			sinkConfs = []config.Sink{{Type: TypeLog}}
+		} else {
+			sinkConfs = []config.Sink{{Type: TypePostgres}}
+		}
+	}
+
+	sinks := make([]Sink, 0, len(sinkConfs))
+	for _, sinkConf := range sinkConfs {
+		s, err := newSink(conf, sinkConf, dbc)
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, errors.Wrapf(err, "init %s sink", sinkConf.Type)
+		}
+		log.WithField("type", sinkConf.Type).WithField("path", sinkConf.Path).Infoln("Initialized result sink")
+		sinks = append(sinks, s)
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+
+	return Multi(sinks...), nil
+}
+
+func newSink(conf *config.Config, sinkConf config.Sink, dbc *db.Client) (Sink, error) {
+	switch sinkConf.Type {
+	case TypePostgres:
+		if conf.Database.DryRun {
+			return nil, fmt.Errorf("can't write to postgres in dry-run mode")
+		}
+		return NewPostgres(dbc), nil
+	case TypeSQLite:
+		return NewSQLite(sinkConf.Path)
+	case TypeJSONL:
+		return NewJSONL(sinkConf.Path)
+	case TypeCSV:
+		return NewCSV(sinkConf.Path)
+	case TypeLog:
+		return NewLog(), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sinkConf.Type)
+	}
+}
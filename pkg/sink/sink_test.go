@@ -0,0 +1,257 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+func testLookup(probe *Probe) *Lookup {
+	return &Lookup{
+		Probe:          probe,
+		PeerID:         "12D3KooWsniffer",
+		MultiAddresses: []string{"/ip4/5.6.7.8/udp/4001/quic"},
+		Type:           "find_node",
+		SeenAt:         time.Date(2022, 10, 1, 12, 0, 30, 0, time.UTC),
+	}
+}
+
+func testResults() (*Probe, *Sighting) {
+	probe := &Probe{
+		TargetType:      "gateway",
+		TargetName:      "ipfs.io",
+		CID:             "bafkreitest",
+		StartedAt:       time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+		EndedAt:         time.Date(2022, 10, 1, 12, 10, 0, 0, time.UTC),
+		Outcome:         "success",
+		Verification:    "valid",
+		ProvideDuration: 1500 * time.Millisecond,
+		HTTPResponse: &HTTPResponse{
+			StatusCode: 200,
+			URL:        "https://bafkreitest.ipfs.dweb.link/",
+			Redirects:  []string{"https://dweb.link/ipfs/bafkreitest"},
+			Headers:    map[string]string{"Server": "nginx"},
+			TLSVersion: "TLS 1.3",
+			Bytes:      42,
+		},
+	}
+
+	sighting := &Sighting{
+		Probe:            probe,
+		PeerID:           "12D3KooWtest",
+		AgentVersion:     "kubo/0.16.0",
+		Protocols:        []string{"/ipfs/bitswap/1.2.0", "/ipfs/kad/1.0.0"},
+		MultiAddresses:   []string{"/ip4/1.2.3.4/tcp/4001"},
+		IPAddresses:      []string{"1.2.3.4"},
+		Countries:        []string{"DE"},
+		Continents:       []string{"EU"},
+		ASNs:             []int64{1234},
+		SeenAt:           time.Date(2022, 10, 1, 12, 1, 0, 0, time.UTC),
+		Transport:        "tcp",
+		IPFamily:         "ipv4",
+		Identified:       true,
+		Direction:        "inbound",
+		SecurityProtocol: "/noise",
+		Muxer:            "/yamux/1.0.0",
+		ConnOpenedAt:     utils.Ptr(time.Date(2022, 10, 1, 12, 0, 50, 0, time.UTC)),
+		PingRTT:          25 * time.Millisecond,
+		Want: &Want{
+			Type:         "have",
+			Priority:     10,
+			SendDontHave: true,
+		},
+	}
+
+	return probe, sighting
+}
+
+func writeResults(t *testing.T, s Sink) {
+	ctx := context.Background()
+	probe, sighting := testResults()
+
+	require.NoError(t, s.StartProbe(ctx, probe))
+	require.NoError(t, s.TrackSighting(ctx, sighting))
+	require.NoError(t, s.FinishSighting(ctx, sighting))
+	require.NoError(t, s.TrackLookup(ctx, testLookup(probe)))
+	require.NoError(t, s.FinishProbe(ctx, probe))
+	require.NoError(t, s.Close())
+}
+
+func TestJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+
+	s, err := NewJSONL(path)
+	require.NoError(t, err)
+	writeResults(t, s)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, "sighting", lines[0]["type"])
+	assert.Equal(t, "bafkreitest", lines[0]["cid"])
+	assert.Equal(t, "ipfs.io", lines[0]["target_name"])
+	assert.Equal(t, "12D3KooWtest", lines[0]["peer_id"])
+	assert.Equal(t, "have", lines[0]["want"].(map[string]any)["type"])
+	assert.Equal(t, "2022-10-01T12:00:50Z", lines[0]["conn_opened_at"])
+
+	assert.Equal(t, "lookup", lines[1]["type"])
+	assert.Equal(t, "bafkreitest", lines[1]["cid"])
+	assert.Equal(t, "12D3KooWsniffer", lines[1]["peer_id"])
+	assert.Equal(t, "find_node", lines[1]["lookup_type"])
+
+	assert.Equal(t, "probe", lines[2]["type"])
+	assert.Equal(t, "bafkreitest", lines[2]["cid"])
+	assert.Equal(t, "success", lines[2]["outcome"])
+	assert.Equal(t, float64(1500*time.Millisecond), lines[2]["provide_duration_ns"])
+	assert.Equal(t, float64(200), lines[2]["http_response"].(map[string]any)["status_code"])
+}
+
+func TestJSONL_noConn(t *testing.T) {
+	probe, _ := testResults()
+
+	var buf bytes.Buffer
+	s := &JSONL{w: &buf, enc: json.NewEncoder(&buf)}
+	require.NoError(t, s.FinishSighting(context.Background(), &Sighting{Probe: probe, PeerID: "12D3KooWtest"}))
+
+	line := map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.NotContains(t, line, "conn_opened_at")
+}
+
+func TestCSV(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "results")
+
+	s, err := NewCSV(dir)
+	require.NoError(t, err)
+	writeResults(t, s)
+
+	// Appending to existing files must not repeat the header
+	s, err = NewCSV(dir)
+	require.NoError(t, err)
+	writeResults(t, s)
+
+	probes := readCSV(t, filepath.Join(dir, CSVProbesFile))
+	require.Len(t, probes, 3)
+	assert.Equal(t, csvProbeHeader, probes[0])
+	assert.Equal(t, []string{
+		"bafkreitest", "gateway", "ipfs.io", "2022-10-01T12:00:00Z", "2022-10-01T12:10:00Z", "success", "valid", "", "1500", "", "",
+		"200", "https://bafkreitest.ipfs.dweb.link/", "https://dweb.link/ipfs/bafkreitest", `{"Server":"nginx"}`, "TLS 1.3", "42",
+	}, probes[1])
+
+	sightings := readCSV(t, filepath.Join(dir, CSVSightingsFile))
+	require.Len(t, sightings, 3)
+	assert.Equal(t, csvSightingHeader, sightings[0])
+	assert.Equal(t, []string{
+		"bafkreitest", "gateway", "ipfs.io", "12D3KooWtest", "kubo/0.16.0", "/ipfs/bitswap/1.2.0;/ipfs/kad/1.0.0",
+		"/ip4/1.2.3.4/tcp/4001", "1.2.3.4", "DE", "EU", "1234", "2022-10-01T12:01:00Z", "tcp", "ipv4", "true", "inbound",
+		"/noise", "/yamux/1.0.0", "2022-10-01T12:00:50Z", "false", "25", "have", "10", "true", "", "",
+	}, sightings[1])
+
+	lookups := readCSV(t, filepath.Join(dir, CSVLookupsFile))
+	require.Len(t, lookups, 3)
+	assert.Equal(t, csvLookupHeader, lookups[0])
+	assert.Equal(t, []string{
+		"bafkreitest", "gateway", "ipfs.io", "12D3KooWsniffer", "", "/ip4/5.6.7.8/udp/4001/quic", "find_node", "2022-10-01T12:00:30Z",
+	}, lookups[1])
+}
+
+func readCSV(t *testing.T, path string) [][]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+
+	return records
+}
+
+func TestNew(t *testing.T) {
+	conf := config.DefaultConfig
+	conf.Database.DryRun = true
+
+	s, err := New(&conf, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &Log{}, s)
+
+	conf.Sinks = []config.Sink{{Type: TypePostgres}}
+	_, err = New(&conf, nil)
+	assert.Error(t, err)
+
+	conf.Sinks = []config.Sink{{Type: "unknown"}}
+	_, err = New(&conf, nil)
+	assert.Error(t, err)
+
+	conf.Sinks = []config.Sink{{Type: TypeLog}, {Type: TypeJSONL, Path: filepath.Join(t.TempDir(), "results.jsonl")}}
+	s, err = New(&conf, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &multi{}, s)
+	writeResults(t, s)
+}
+
+// failingSink is a sink that fails to write any results.
+type failingSink struct{}
+
+var errFailingSink = errors.New("failing sink")
+
+func (failingSink) StartProbe(context.Context, *Probe) error {
+	return errFailingSink
+}
+
+func (failingSink) TrackSighting(context.Context, *Sighting) error {
+	return errFailingSink
+}
+
+func (failingSink) FinishSighting(context.Context, *Sighting) error {
+	return errFailingSink
+}
+
+func (failingSink) TrackLookup(context.Context, *Lookup) error {
+	return errFailingSink
+}
+
+func (failingSink) FinishProbe(context.Context, *Probe) error {
+	return errFailingSink
+}
+
+func (failingSink) Close() error {
+	return nil
+}
+
+func TestMulti_failingSink(t *testing.T) {
+	var buf bytes.Buffer
+	jsonl := &JSONL{w: &buf, enc: json.NewEncoder(&buf)}
+
+	// The results must still be written to the working sink
+	writeResults(t, Multi(failingSink{}, jsonl))
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+
+	// If all sinks fail, the error is returned
+	probe, _ := testResults()
+	err := Multi(failingSink{}, failingSink{}).StartProbe(context.Background(), probe)
+	assert.ErrorIs(t, err, errFailingSink)
+}
@@ -0,0 +1,304 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/db"
+)
+
+// SQLite writes the results to an embedded SQLite database with the same tables as the postgres
+// database. Arrays are stored as JSON arrays, timestamps as RFC 3339 strings and durations in seconds.
+type SQLite struct {
+	dbh *sql.DB
+	ids *rowIDs
+}
+
+var _ Sink = (*SQLite)(nil)
+
+// NewSQLite opens or creates the SQLite database at the given path.
+func NewSQLite(path string) (*SQLite, error) {
+	if path == "" {
+		path = "antares.db"
+	}
+
+	dbh, err := db.OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLite{dbh: dbh, ids: newRowIDs()}, nil
+}
+
+// StartProbe inserts a new probe run into the database and remembers its ID.
+func (s *SQLite) StartProbe(ctx context.Context, p *Probe) error {
+	query := `INSERT INTO probes (target_type, target_name, cid, started_at, created_at) VALUES (?, ?, ?, ?, ?) RETURNING id`
+
+	var id int64
+	err := s.dbh.QueryRowContext(ctx, query, p.TargetType, p.TargetName, p.CID, sqliteTime(p.StartedAt), sqliteTime(time.Now())).Scan(&id)
+	if err != nil {
+		return errors.Wrap(err, "insert probe")
+	}
+	s.ids.setProbe(p, id)
+
+	return nil
+}
+
+// sqlitePeerUpsert inserts a new peer or updates the latest state of an existing one. Like for postgres,
+// empty values don't overwrite previously known ones.
+const sqlitePeerUpsert = `
+INSERT INTO peers (multi_hash, agent_version, protocols, multi_addresses, ip_addresses, countries, continents, asns,
+                   target_type, target_name, last_seen_at, updated_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (multi_hash, target_name) DO UPDATE SET
+    agent_version   = COALESCE(excluded.agent_version, agent_version),
+    protocols       = CASE WHEN excluded.protocols = '[]' THEN protocols ELSE excluded.protocols END,
+    multi_addresses = CASE WHEN excluded.multi_addresses = '[]' THEN multi_addresses ELSE excluded.multi_addresses END,
+    ip_addresses    = CASE WHEN excluded.ip_addresses = '[]' THEN ip_addresses ELSE excluded.ip_addresses END,
+    countries       = CASE WHEN excluded.countries = '[]' THEN countries ELSE excluded.countries END,
+    continents      = CASE WHEN excluded.continents = '[]' THEN continents ELSE excluded.continents END,
+    asns            = CASE WHEN excluded.asns = '[]' THEN asns ELSE excluded.asns END,
+    last_seen_at    = excluded.last_seen_at,
+    updated_at      = excluded.updated_at
+RETURNING id`
+
+// TrackSighting updates the latest state of the given peer in the `peers` table and appends a new sighting
+// of that peer to the `sightings` table.
+func (s *SQLite) TrackSighting(ctx context.Context, sighting *Sighting) error {
+	probeID, err := s.ids.probe(sighting.Probe)
+	if err != nil {
+		return err
+	}
+
+	txn, err := s.dbh.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin txn")
+	}
+	defer func() {
+		if err = txn.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.WithError(err).Warnln("Error rolling back transaction")
+		}
+	}()
+
+	agentVersion := sql.NullString{String: sighting.AgentVersion, Valid: sighting.AgentVersion != ""}
+	protocols := sqliteArray(sighting.Protocols)
+	maddrs := sqliteArray(sighting.MultiAddresses)
+	ipAddresses := sqliteArray(sighting.IPAddresses)
+	countries := sqliteArray(sighting.Countries)
+	continents := sqliteArray(sighting.Continents)
+	asns := sqliteArray(sighting.ASNs)
+	seenAt := sqliteTime(sighting.SeenAt)
+	now := sqliteTime(time.Now())
+
+	var peerID int64
+	err = txn.QueryRowContext(ctx, sqlitePeerUpsert, sighting.PeerID, agentVersion, protocols, maddrs, ipAddresses,
+		countries, continents, asns, sighting.Probe.TargetType, sighting.Probe.TargetName, seenAt, now, now).Scan(&peerID)
+	if err != nil {
+		return errors.Wrap(err, "upsert peer")
+	}
+
+	var (
+		direction        sql.NullString
+		securityProtocol sql.NullString
+		muxer            sql.NullString
+		connOpenedAt     sql.NullString
+		relayed          sql.NullBool
+	)
+	if sighting.ConnOpenedAt != nil {
+		direction = sql.NullString{String: sighting.Direction, Valid: sighting.Direction != ""}
+		securityProtocol = sql.NullString{String: sighting.SecurityProtocol, Valid: sighting.SecurityProtocol != ""}
+		muxer = sql.NullString{String: sighting.Muxer, Valid: sighting.Muxer != ""}
+		connOpenedAt = sqliteNullTime(*sighting.ConnOpenedAt)
+		relayed = sql.NullBool{Bool: sighting.Relayed, Valid: true}
+	}
+
+	var (
+		wantType     sql.NullString
+		wantPriority sql.NullInt32
+		sendDontHave sql.NullBool
+	)
+	if sighting.Want != nil {
+		wantType = sql.NullString{String: sighting.Want.Type, Valid: true}
+		wantPriority = sql.NullInt32{Int32: sighting.Want.Priority, Valid: true}
+		sendDontHave = sql.NullBool{Bool: sighting.Want.SendDontHave, Valid: true}
+	}
+
+	query := `
+INSERT INTO sightings (probe_id, peer_id, agent_version, protocols, multi_addresses, ip_addresses, countries,
+                       continents, asns, seen_at, transport, ip_family, identified, direction, security_protocol,
+                       muxer, conn_opened_at, relayed, ping_rtt, want_type, want_priority, send_dont_have, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id`
+	var id int64
+	err = txn.QueryRowContext(ctx, query, probeID, peerID, agentVersion, protocols, maddrs, ipAddresses,
+		countries, continents, asns, seenAt, sql.NullString{String: sighting.Transport, Valid: sighting.Transport != ""},
+		sql.NullString{String: sighting.IPFamily, Valid: sighting.IPFamily != ""}, sighting.Identified, direction,
+		securityProtocol, muxer, connOpenedAt, relayed, sqliteSeconds(sighting.PingRTT), wantType,
+		wantPriority, sendDontHave, now).Scan(&id)
+	if err != nil {
+		return errors.Wrap(err, "insert sighting")
+	}
+
+	if err = txn.Commit(); err != nil {
+		return errors.Wrap(err, "commit txn")
+	}
+	s.ids.setSighting(sighting, id)
+
+	return nil
+}
+
+// FinishSighting persists the Bitswap interactions that happened after the sighting was tracked.
+func (s *SQLite) FinishSighting(ctx context.Context, sighting *Sighting) error {
+	defer s.ids.forgetSighting(sighting)
+
+	if sighting.Want == nil || (sighting.Want.CanceledAt.IsZero() && sighting.Want.BlockSentAt.IsZero()) {
+		return nil
+	}
+
+	id, err := s.ids.sighting(sighting)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE sightings SET canceled_at = ?, block_sent_at = ? WHERE id = ?`
+	_, err = s.dbh.ExecContext(ctx, query, sqliteNullTime(sighting.Want.CanceledAt), sqliteNullTime(sighting.Want.BlockSentAt), id)
+	if err != nil {
+		return errors.Wrap(err, "update sighting")
+	}
+
+	return nil
+}
+
+// TrackLookup appends the given lookup to the `lookups` table.
+func (s *SQLite) TrackLookup(ctx context.Context, l *Lookup) error {
+	probeID, err := s.ids.probe(l.Probe)
+	if err != nil {
+		return err
+	}
+
+	query := `
+INSERT INTO lookups (probe_id, multi_hash, agent_version, multi_addresses, type, seen_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err = s.dbh.ExecContext(ctx, query,
+		probeID,
+		l.PeerID,
+		sql.NullString{String: l.AgentVersion, Valid: l.AgentVersion != ""},
+		sqliteArray(l.MultiAddresses),
+		l.Type,
+		sqliteTime(l.SeenAt),
+		sqliteTime(time.Now()),
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert lookup")
+	}
+
+	return nil
+}
+
+// FinishProbe persists the end, outcome and timings of the given probe.
+func (s *SQLite) FinishProbe(ctx context.Context, p *Probe) error {
+	defer s.ids.forgetProbe(p)
+
+	id, err := s.ids.probe(p)
+	if err != nil {
+		return err
+	}
+
+	var (
+		statusCode sql.NullInt64
+		url        sql.NullString
+		redirects  sql.NullString
+		headers    sql.NullString
+		tlsVersion sql.NullString
+		bytes      sql.NullInt64
+	)
+	if r := p.HTTPResponse; r != nil {
+		statusCode = sql.NullInt64{Int64: int64(r.StatusCode), Valid: true}
+		url = sql.NullString{String: r.URL, Valid: true}
+		redirects = sql.NullString{String: sqliteArray(r.Redirects), Valid: len(r.Redirects) > 0}
+		tlsVersion = sql.NullString{String: r.TLSVersion, Valid: r.TLSVersion != ""}
+		bytes = sql.NullInt64{Int64: r.Bytes, Valid: true}
+		if len(r.Headers) > 0 {
+			data, err := json.Marshal(r.Headers)
+			if err != nil {
+				return errors.Wrap(err, "marshal http headers")
+			}
+			headers = sql.NullString{String: string(data), Valid: true}
+		}
+	}
+
+	query := `
+UPDATE probes
+SET outcome            = ?,
+    verification       = ?,
+    error              = ?,
+    ended_at           = ?,
+    provide_duration   = ?,
+    operation_duration = ?,
+    first_want_latency = ?,
+    http_status_code   = ?,
+    http_url           = ?,
+    http_redirects     = ?,
+    http_headers       = ?,
+    http_tls_version   = ?,
+    http_bytes         = ?
+WHERE id = ?`
+	_, err = s.dbh.ExecContext(ctx, query,
+		p.Outcome,
+		sql.NullString{String: p.Verification, Valid: p.Verification != ""},
+		sql.NullString{String: p.Error, Valid: p.Error != ""},
+		sqliteTime(p.EndedAt),
+		sqliteSeconds(p.ProvideDuration),
+		sqliteSeconds(p.OperationDuration),
+		sqliteSeconds(p.FirstWantLatency),
+		statusCode,
+		url,
+		redirects,
+		headers,
+		tlsVersion,
+		bytes,
+		id,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update probe")
+	}
+
+	return nil
+}
+
+func (s *SQLite) Close() error {
+	return s.dbh.Close()
+}
+
+// sqliteArray encodes the given slice as a JSON array. A nil slice is encoded as an empty array.
+func sqliteArray[T any](values []T) string {
+	if values == nil {
+		return "[]"
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		// can't happen for slices of strings and integers
+		panic(err)
+	}
+	return string(data)
+}
+
+// sqliteTime formats the given time as an RFC 3339 string in UTC, so that the strings can be compared.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// sqliteNullTime is like sqliteTime but maps a zero time to NULL.
+func sqliteNullTime(t time.Time) sql.NullString {
+	return sql.NullString{String: sqliteTime(t), Valid: !t.IsZero()}
+}
+
+// sqliteSeconds converts the given duration to seconds. A zero duration is mapped to NULL.
+func sqliteSeconds(d time.Duration) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: d.Seconds(), Valid: d != 0}
+}
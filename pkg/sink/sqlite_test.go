@@ -0,0 +1,154 @@
+package sink
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "antares.db")
+
+	s, err := NewSQLite(path)
+	require.NoError(t, err)
+
+	probe, sighting := testResults()
+	require.NoError(t, s.StartProbe(ctx, probe))
+	probeID, err := s.ids.probe(probe)
+	require.NoError(t, err)
+	assert.NotZero(t, probeID)
+
+	require.NoError(t, s.TrackSighting(ctx, sighting))
+	sightingID, err := s.ids.sighting(sighting)
+	require.NoError(t, err)
+	assert.NotZero(t, sightingID)
+
+	// A second sighting of the same peer without agent version and addresses must not erase them
+	sighting2 := &Sighting{
+		Probe:  probe,
+		PeerID: sighting.PeerID,
+		SeenAt: time.Date(2022, 10, 1, 12, 5, 0, 0, time.UTC),
+	}
+	require.NoError(t, s.TrackSighting(ctx, sighting2))
+
+	sighting.Want.BlockSentAt = time.Date(2022, 10, 1, 12, 2, 0, 0, time.UTC)
+	require.NoError(t, s.FinishSighting(ctx, sighting))
+	require.NoError(t, s.TrackLookup(ctx, testLookup(probe)))
+	require.NoError(t, s.FinishProbe(ctx, probe))
+
+	// The IDs of finished probes and their sightings are forgotten
+	assert.Empty(t, s.ids.probes)
+	assert.Empty(t, s.ids.sightings)
+	require.NoError(t, s.Close())
+
+	// Reopening must not fail, because all migrations were already applied
+	s, err = NewSQLite(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	var (
+		outcome         string
+		verification    string
+		provideDuration float64
+		httpHeaders     string
+	)
+	err = s.dbh.QueryRow(`SELECT outcome, verification, provide_duration, http_headers FROM probes WHERE id = ?`, probeID).Scan(&outcome, &verification, &provideDuration, &httpHeaders)
+	require.NoError(t, err)
+	assert.Equal(t, "success", outcome)
+	assert.Equal(t, "valid", verification)
+	assert.JSONEq(t, `{"Server":"nginx"}`, httpHeaders)
+	assert.Equal(t, 1.5, provideDuration)
+
+	var (
+		peerCount    int
+		agentVersion string
+		maddrs       string
+		lastSeenAt   string
+	)
+	err = s.dbh.QueryRow(`SELECT count(*), agent_version, multi_addresses, last_seen_at FROM peers`).Scan(&peerCount, &agentVersion, &maddrs, &lastSeenAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, peerCount)
+	assert.Equal(t, "kubo/0.16.0", agentVersion)
+	assert.Equal(t, `["/ip4/1.2.3.4/tcp/4001"]`, maddrs)
+	assert.Equal(t, "2022-10-01T12:05:00Z", lastSeenAt)
+
+	var (
+		sightingCount int
+		blockSentAt   string
+		wantType      string
+		transport     string
+		ipFamily      string
+		identified    bool
+		direction     string
+		muxer         string
+		connOpenedAt  string
+		relayed       bool
+		pingRTT       float64
+	)
+	err = s.dbh.QueryRow(`SELECT count(*) FROM sightings WHERE probe_id = ?`, probeID).Scan(&sightingCount)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sightingCount)
+
+	err = s.dbh.QueryRow(`SELECT block_sent_at, want_type, transport, ip_family, identified FROM sightings WHERE id = ?`, sightingID).
+		Scan(&blockSentAt, &wantType, &transport, &ipFamily, &identified)
+	require.NoError(t, err)
+	assert.Equal(t, "2022-10-01T12:02:00Z", blockSentAt)
+	assert.Equal(t, "have", wantType)
+	assert.Equal(t, "tcp", transport)
+	assert.Equal(t, "ipv4", ipFamily)
+	assert.True(t, identified)
+
+	err = s.dbh.QueryRow(`SELECT direction, muxer, conn_opened_at, relayed, ping_rtt FROM sightings WHERE id = ?`, sightingID).
+		Scan(&direction, &muxer, &connOpenedAt, &relayed, &pingRTT)
+	require.NoError(t, err)
+	assert.Equal(t, "inbound", direction)
+	assert.Equal(t, "/yamux/1.0.0", muxer)
+	assert.Equal(t, "2022-10-01T12:00:50Z", connOpenedAt)
+	assert.False(t, relayed)
+	assert.Equal(t, 0.025, pingRTT)
+
+	var lookupType string
+	err = s.dbh.QueryRow(`SELECT type FROM lookups WHERE probe_id = ? AND multi_hash = ?`, probeID, "12D3KooWsniffer").Scan(&lookupType)
+	require.NoError(t, err)
+	assert.Equal(t, "find_node", lookupType)
+}
+
+func TestSQLite_multi(t *testing.T) {
+	ctx := context.Background()
+
+	s1, err := NewSQLite(filepath.Join(t.TempDir(), "antares.db"))
+	require.NoError(t, err)
+	defer s1.Close()
+
+	s2, err := NewSQLite(filepath.Join(t.TempDir(), "antares.db"))
+	require.NoError(t, err)
+	defer s2.Close()
+
+	// Let the IDs of both databases diverge
+	other, _ := testResults()
+	require.NoError(t, s1.StartProbe(ctx, other))
+	require.NoError(t, s1.FinishProbe(ctx, other))
+
+	// Both sinks receive the same probe and sighting
+	m := Multi(s1, s2)
+	probe, sighting := testResults()
+	sighting.Want.BlockSentAt = time.Date(2022, 10, 1, 12, 2, 0, 0, time.UTC)
+	require.NoError(t, m.StartProbe(ctx, probe))
+	require.NoError(t, m.TrackSighting(ctx, sighting))
+	require.NoError(t, m.FinishSighting(ctx, sighting))
+	require.NoError(t, m.FinishProbe(ctx, probe))
+
+	for _, s := range []*SQLite{s1, s2} {
+		var count int
+		err = s.dbh.QueryRow(`
+SELECT count(*) FROM sightings s JOIN probes p ON s.probe_id = p.id
+WHERE p.outcome = 'success' AND s.block_sent_at IS NOT NULL`).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	}
+}
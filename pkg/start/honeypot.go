@@ -0,0 +1,206 @@
+package start
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	dhtpb "github.com/libp2p/go-libp2p-kad-dht/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/models"
+)
+
+// lookupBufferSize is the number of DHT lookups for a registered CID that are buffered before
+// the honeypot starts dropping them because the probe can't keep up.
+const lookupBufferSize = 100
+
+// lookupTypes maps the DHT request types that the honeypot records to their database representation.
+var lookupTypes = map[dhtpb.Message_MessageType]string{
+	dhtpb.Message_GET_PROVIDERS: models.LookupTypeGetProviders,
+	dhtpb.Message_FIND_NODE:     models.LookupTypeFindNode,
+}
+
+// Lookup captures a DHT request of a peer for a registered CID.
+type Lookup struct {
+	PeerID peer.ID
+	Type   dhtpb.Message_MessageType
+	SeenAt time.Time
+}
+
+// lookupKey identifies a DHT request type of a peer, so that repeated requests are only delivered once.
+type lookupKey struct {
+	peerID peer.ID
+	typ    dhtpb.Message_MessageType
+}
+
+// lookupRegistration holds the state for a single registered CID.
+type lookupRegistration struct {
+	ch   chan *Lookup
+	seen map[lookupKey]struct{}
+}
+
+// The Honeypot inspects incoming DHT requests and detects peers that look up the provider records or
+// closest peers of registered CIDs. This reveals peers that sniff DHT traffic as well as the routing
+// peers of gateways, which are often different from the peers that fetch the content via Bitswap.
+type Honeypot struct {
+	mhsLk sync.Mutex
+	mhs   map[string]*lookupRegistration
+}
+
+func NewHoneypot() *Honeypot {
+	return &Honeypot{
+		mhsLk: sync.Mutex{},
+		mhs:   map[string]*lookupRegistration{},
+	}
+}
+
+// Register starts tracking DHT requests for the multihash of the given CID. The returned channel receives
+// the first request of each type from each distinct peer until the CID is unregistered again.
+func (hp *Honeypot) Register(contentID cid.Cid) <-chan *Lookup {
+	log.WithField("cid", contentID).Debugln("Honeypot registered CID")
+
+	hp.mhsLk.Lock()
+	defer hp.mhsLk.Unlock()
+
+	reg := &lookupRegistration{
+		ch:   make(chan *Lookup, lookupBufferSize),
+		seen: map[lookupKey]struct{}{},
+	}
+	hp.mhs[string(contentID.Hash())] = reg
+
+	return reg.ch
+}
+
+// Unregister stops tracking DHT requests for the given CID and closes the corresponding channel.
+// It is safe to call Unregister multiple times.
+func (hp *Honeypot) Unregister(contentID cid.Cid) {
+	hp.mhsLk.Lock()
+	defer hp.mhsLk.Unlock()
+
+	reg, ok := hp.mhs[string(contentID.Hash())]
+	if !ok {
+		return
+	}
+	log.WithField("cid", contentID).Debugln("Honeypot unregistered CID")
+
+	close(reg.ch)
+	delete(hp.mhs, string(contentID.Hash()))
+}
+
+// Host wraps the given host, so that the honeypot can inspect the requests of all DHT stream
+// handlers that are registered on the returned host. The wrapped host must be passed to the DHT.
+func (hp *Honeypot) Host(h host.Host) host.Host {
+	return &honeypotHost{Host: h, hp: hp}
+}
+
+// handleMessage parses the given DHT message and delivers it if it's a request for a registered CID.
+func (hp *Honeypot) handleMessage(remote peer.ID, data []byte) {
+	hp.mhsLk.Lock()
+	defer hp.mhsLk.Unlock()
+
+	// Don't bother parsing messages if no probe is running
+	if len(hp.mhs) == 0 {
+		return
+	}
+
+	msg := &dhtpb.Message{}
+	if err := msg.Unmarshal(data); err != nil {
+		log.WithError(err).WithField("peerID", remote).Traceln("Honeypot could not parse DHT message")
+		return
+	}
+
+	if _, ok := lookupTypes[msg.GetType()]; !ok {
+		return
+	}
+
+	reg, ok := hp.mhs[string(msg.GetKey())]
+	if !ok {
+		return
+	}
+
+	logEntry := log.WithField("peerID", remote).WithField("type", msg.GetType())
+
+	key := lookupKey{peerID: remote, typ: msg.GetType()}
+	if _, seen := reg.seen[key]; seen {
+		logEntry.Traceln("Honeypot ignored repeated lookup")
+		return
+	}
+	reg.seen[key] = struct{}{}
+
+	lookup := &Lookup{
+		PeerID: remote,
+		Type:   msg.GetType(),
+		SeenAt: time.Now(),
+	}
+
+	select {
+	case reg.ch <- lookup:
+		logEntry.Traceln("Honeypot delivered lookup")
+	default:
+		logEntry.Warnln("Honeypot dropped lookup")
+	}
+}
+
+// honeypotHost wraps the stream handlers that are registered on it, so that
+// the honeypot sees all incoming messages of these streams.
+type honeypotHost struct {
+	host.Host
+	hp *Honeypot
+}
+
+func (h *honeypotHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	h.Host.SetStreamHandler(pid, func(s network.Stream) {
+		handler(&honeypotStream{Stream: s, hp: h.hp})
+	})
+}
+
+// honeypotStream observes all bytes that are read from the underlying stream and
+// passes every complete varint-delimited message to the honeypot.
+type honeypotStream struct {
+	network.Stream
+	hp *Honeypot
+
+	// buf holds the bytes of the message that is currently being read.
+	buf []byte
+
+	// broken is set if the stream contained data we couldn't make sense of. In that
+	// case we stop inspecting it but leave the error handling to the DHT.
+	broken bool
+}
+
+func (s *honeypotStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 && !s.broken {
+		s.buf = append(s.buf, p[:n]...)
+		s.parse()
+	}
+	return n, err
+}
+
+// parse consumes all complete messages in the buffer.
+func (s *honeypotStream) parse() {
+	for {
+		length, n := binary.Uvarint(s.buf)
+		if n == 0 {
+			return // need more data
+		} else if n < 0 || length > network.MessageSizeMax {
+			s.broken = true
+			s.buf = nil
+			return
+		}
+
+		end := n + int(length)
+		if len(s.buf) < end {
+			return // need more data
+		}
+
+		s.hp.handleMessage(s.Conn().RemotePeer(), s.buf[n:end])
+		s.buf = append(s.buf[:0], s.buf[end:]...)
+	}
+}
@@ -0,0 +1,95 @@
+package start
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	dhtpb "github.com/libp2p/go-libp2p-kad-dht/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDHTProtocol = "/ipfs/kad/1.0.0"
+
+func newTestHost(t *testing.T) host.Host {
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+	return h
+}
+
+func TestHoneypot(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hp := NewHoneypot()
+	server := newTestHost(t)
+	client := newTestHost(t)
+
+	// The handler imitates the DHT which must still receive all messages.
+	received := make(chan *dhtpb.Message, 10)
+	hp.Host(server).SetStreamHandler(testDHTProtocol, func(s network.Stream) {
+		defer s.Close()
+		r := msgio.NewVarintReaderSize(s, network.MessageSizeMax)
+		for {
+			data, err := r.ReadMsg()
+			if err != nil {
+				return
+			}
+			msg := &dhtpb.Message{}
+			require.NoError(t, msg.Unmarshal(data))
+			received <- msg
+		}
+	})
+
+	c := testCid(t)
+	otherHash, err := multihash.Sum([]byte("other"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+	chLookup := hp.Register(c)
+
+	require.NoError(t, client.Connect(ctx, peer.AddrInfo{ID: server.ID(), Addrs: server.Addrs()}))
+	s, err := client.NewStream(ctx, server.ID(), testDHTProtocol)
+	require.NoError(t, err)
+
+	msgs := []*dhtpb.Message{
+		{Type: dhtpb.Message_GET_PROVIDERS, Key: c.Hash()},
+		{Type: dhtpb.Message_GET_PROVIDERS, Key: c.Hash()}, // repeated, not delivered again
+		{Type: dhtpb.Message_PUT_VALUE, Key: c.Hash()},     // not a lookup
+		{Type: dhtpb.Message_FIND_NODE, Key: otherHash},    // not registered
+		{Type: dhtpb.Message_FIND_NODE, Key: c.Hash()},
+	}
+	w := msgio.NewVarintWriter(s)
+	for _, msg := range msgs {
+		data, err := msg.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, w.WriteMsg(data))
+	}
+	require.NoError(t, s.CloseWrite())
+
+	for range msgs {
+		select {
+		case <-received:
+		case <-ctx.Done():
+			t.Fatal("handler did not receive all messages")
+		}
+	}
+
+	hp.Unregister(c)
+	var lookups []*Lookup
+	for lookup := range chLookup {
+		lookups = append(lookups, lookup)
+	}
+
+	require.Len(t, lookups, 2)
+	assert.Equal(t, client.ID(), lookups[0].PeerID)
+	assert.Equal(t, dhtpb.Message_GET_PROVIDERS, lookups[0].Type)
+	assert.Equal(t, client.ID(), lookups[1].PeerID)
+	assert.Equal(t, dhtpb.Message_FIND_NODE, lookups[1].Type)
+}
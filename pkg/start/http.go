@@ -0,0 +1,113 @@
+package start
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+// NewHTTPClient constructs an HTTP client from the given configuration.
+func NewHTTPClient(conf config.HTTP) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if conf.ProxyURL != "" {
+		proxyURL, err := url.Parse(conf.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse proxy url")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if conf.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		data, err := os.ReadFile(conf.CABundle)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca bundle")
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in ca bundle %s", conf.CABundle)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	if conf.DisableHTTP2 {
+		// A non-nil empty map prevents the upgrade to HTTP/2 during the TLS handshake.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if conf.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = conf.MaxIdleConnsPerHost
+	}
+
+	var rt http.RoundTripper = transport
+	if conf.UserAgent != "" {
+		rt = &userAgentTransport{userAgent: conf.UserAgent, next: transport}
+	}
+
+	return &http.Client{
+		Transport: rt,
+		Timeout:   conf.Timeout,
+	}, nil
+}
+
+// userAgentTransport sets the User-Agent header of all requests that don't already have one.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the given request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.next.RoundTrip(req)
+}
+
+// httpClients hands out HTTP clients for the configuration overrides of targets. Targets with
+// the same effective configuration share a client, so that they can reuse connections.
+type httpClients struct {
+	defaults config.HTTP
+	clients  map[config.HTTP]*http.Client
+}
+
+func newHTTPClients(defaults config.HTTP) *httpClients {
+	return &httpClients{
+		defaults: defaults,
+		clients:  map[config.HTTP]*http.Client{},
+	}
+}
+
+// get returns the client for the given configuration overrides.
+func (h *httpClients) get(overrides config.HTTP) (*http.Client, error) {
+	conf := overrides.WithDefaults(h.defaults)
+	if client, found := h.clients[conf]; found {
+		return client, nil
+	}
+
+	client, err := NewHTTPClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	h.clients[conf] = client
+
+	return client, nil
+}
@@ -0,0 +1,116 @@
+package start
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+func TestNewHTTPClient_UserAgent(t *testing.T) {
+	var userAgents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+	}))
+	defer ts.Close()
+
+	client, err := NewHTTPClient(config.HTTP{UserAgent: "antares/test", Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.Timeout)
+
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	// An explicitly set User-Agent must not be overwritten
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "custom")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{"antares/test", "custom"}, userAgents)
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(config.HTTP{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://gateway.example.com/ipfs/test")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{"http://gateway.example.com/ipfs/test"}, proxied)
+
+	_, err = NewHTTPClient(config.HTTP{ProxyURL: "://invalid"})
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClient_CABundle(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	// The test server's certificate isn't trusted by default
+	client, err := NewHTTPClient(config.HTTP{})
+	require.NoError(t, err)
+	_, err = client.Get(ts.URL)
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	for _, disableHTTP2 := range []bool{false, true} {
+		client, err = NewHTTPClient(config.HTTP{CABundle: path, DisableHTTP2: disableHTTP2})
+		require.NoError(t, err)
+
+		resp, err := client.Get(ts.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		if disableHTTP2 {
+			assert.Equal(t, 1, resp.ProtoMajor)
+		} else {
+			assert.Equal(t, 2, resp.ProtoMajor)
+		}
+	}
+
+	_, err = NewHTTPClient(config.HTTP{CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+}
+
+func TestHTTPClients_get(t *testing.T) {
+	clients := newHTTPClients(config.HTTP{Timeout: time.Minute})
+
+	shared, err := clients.get(config.HTTP{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, shared.Timeout)
+
+	same, err := clients.get(config.HTTP{Timeout: time.Minute})
+	require.NoError(t, err)
+	assert.Same(t, shared, same)
+
+	override, err := clients.get(config.HTTP{Timeout: time.Hour})
+	require.NoError(t, err)
+	assert.NotSame(t, shared, override)
+	assert.Equal(t, time.Hour, override.Timeout)
+}
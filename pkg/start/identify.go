@@ -0,0 +1,170 @@
+package start
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// IdentifyTimeout is the maximum time that a probe waits for the identify exchange with a peer before it records
+// the peer anyway. Without the identify exchange, the agent version and protocols of the peer are unknown.
+var IdentifyTimeout = 10 * time.Second
+
+// The Identifier keeps track of the identify exchanges with connected peers. libp2p identifies each new connection
+// right after it was established, which races with the Bitswap want of a peer that has just connected to request
+// a CID. Probes use the Identifier to wait until the agent version and protocols of the peer are known.
+type Identifier struct {
+	host host.Host
+
+	resultsLk sync.Mutex
+
+	// Whether the identify exchange with a connected peer succeeded.
+	results map[peer.ID]bool
+
+	// The channels of the callers that wait for the identify exchange with a peer to complete.
+	waiters map[peer.ID][]chan bool
+}
+
+// NewIdentifier initializes a new Identifier that follows the identify exchanges of the given host
+// until the given context is canceled.
+func NewIdentifier(ctx context.Context, h host.Host) (*Identifier, error) {
+	sub, err := h.EventBus().Subscribe([]any{
+		new(event.EvtPeerIdentificationCompleted),
+		new(event.EvtPeerIdentificationFailed),
+		new(event.EvtPeerConnectednessChanged),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribe to identify events")
+	}
+
+	id := &Identifier{
+		host:    h,
+		results: map[peer.ID]bool{},
+		waiters: map[peer.ID][]chan bool{},
+	}
+	go id.consume(ctx, sub)
+
+	return id, nil
+}
+
+// consume handles the events of the given subscription until the given context is canceled.
+func (id *Identifier) consume(ctx context.Context, sub event.Subscription) {
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, more := <-sub.Out():
+			if !more {
+				return
+			}
+
+			switch evt := evt.(type) {
+			case event.EvtPeerIdentificationCompleted:
+				id.identified(evt.Peer, true)
+			case event.EvtPeerIdentificationFailed:
+				log.WithError(evt.Reason).WithField("peerID", evt.Peer).Debugln("Identify exchange failed")
+				id.identified(evt.Peer, false)
+			case event.EvtPeerConnectednessChanged:
+				if evt.Connectedness == network.NotConnected {
+					id.forget(evt.Peer)
+				}
+			}
+		}
+	}
+}
+
+// identified records the outcome of an identify exchange with the given peer and notifies all callers that wait for it.
+func (id *Identifier) identified(peerID peer.ID, success bool) {
+	id.resultsLk.Lock()
+	defer id.resultsLk.Unlock()
+
+	// A peer stays identified if the exchange fails on another of its connections
+	success = success || id.results[peerID]
+	id.results[peerID] = success
+
+	for _, ch := range id.waiters[peerID] {
+		ch <- success
+	}
+	delete(id.waiters, peerID)
+}
+
+// forget removes the outcome of the identify exchange with the given peer after we have disconnected from it.
+func (id *Identifier) forget(peerID peer.ID) {
+	id.resultsLk.Lock()
+	defer id.resultsLk.Unlock()
+
+	delete(id.results, peerID)
+}
+
+// Wait waits until the identify exchange with the given peer has completed and returns whether it succeeded.
+// It returns false right away if we're not connected to the peer and true right away if the peer was identified
+// before the Identifier was started. It returns false if the exchange didn't complete within the given timeout
+// or before the context was canceled.
+func (id *Identifier) Wait(ctx context.Context, peerID peer.ID, timeout time.Duration) bool {
+	id.resultsLk.Lock()
+	if success, found := id.results[peerID]; found {
+		id.resultsLk.Unlock()
+		return success
+	} else if id.host.Network().Connectedness(peerID) != network.Connected {
+		id.resultsLk.Unlock()
+		return false
+	} else if id.inPeerstore(peerID) {
+		id.resultsLk.Unlock()
+		return true
+	}
+
+	ch := make(chan bool, 1)
+	id.waiters[peerID] = append(id.waiters[peerID], ch)
+	id.resultsLk.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case success := <-ch:
+		return success
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	id.resultsLk.Lock()
+	defer id.resultsLk.Unlock()
+
+	for i, waiter := range id.waiters[peerID] {
+		if waiter == ch {
+			id.waiters[peerID] = append(id.waiters[peerID][:i], id.waiters[peerID][i+1:]...)
+			break
+		}
+	}
+	if len(id.waiters[peerID]) == 0 {
+		delete(id.waiters, peerID)
+	}
+
+	// The exchange may have completed in the meantime
+	select {
+	case success := <-ch:
+		return success
+	default:
+		return false
+	}
+}
+
+// inPeerstore returns true if the peerstore already knows the agent version or protocols of the given peer. This
+// is the case for peers that were identified before the Identifier subscribed to the identify events.
+func (id *Identifier) inPeerstore(peerID peer.ID) bool {
+	if av, err := id.host.Peerstore().Get(peerID, "AgentVersion"); err == nil && av != "" {
+		return true
+	}
+
+	protocols, err := id.host.Peerstore().GetProtocols(peerID)
+	return err == nil && len(protocols) > 0
+}
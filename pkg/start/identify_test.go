@@ -0,0 +1,101 @@
+package start
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifier_Wait(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := newTestHost(t)
+	remote := newTestHost(t)
+
+	id, err := NewIdentifier(ctx, h)
+	require.NoError(t, err)
+
+	// There is no identify exchange with peers that we aren't connected to
+	start := time.Now()
+	assert.False(t, id.Wait(ctx, remote.ID(), time.Minute))
+	assert.Less(t, time.Since(start), time.Second)
+
+	require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+	assert.True(t, id.Wait(ctx, remote.ID(), 10*time.Second))
+
+	_, err = h.Peerstore().Get(remote.ID(), "AgentVersion")
+	assert.NoError(t, err)
+
+	// The outcome is forgotten after disconnecting
+	require.NoError(t, h.Network().ClosePeer(remote.ID()))
+	assert.Eventually(t, func() bool {
+		return !id.Wait(ctx, remote.ID(), time.Millisecond)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestIdentifier_identified(t *testing.T) {
+	h := newTestHost(t)
+	remote := newTestHost(t)
+	require.NoError(t, h.Connect(context.Background(), peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+
+	// Without subscribing to the events of the host and without the results of the identify
+	// exchange in the peerstore, only the recorded outcomes count
+	assert.Eventually(t, func() bool {
+		_, err := h.Peerstore().Get(remote.ID(), "AgentVersion")
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	h.Peerstore().RemovePeer(remote.ID())
+	id := &Identifier{host: h, results: map[peer.ID]bool{}, waiters: map[peer.ID][]chan bool{}}
+
+	assert.False(t, id.Wait(context.Background(), remote.ID(), 10*time.Millisecond))
+	assert.Empty(t, id.waiters)
+
+	done := make(chan bool)
+	go func() { done <- id.Wait(context.Background(), remote.ID(), time.Minute) }()
+	assert.Eventually(t, func() bool {
+		id.resultsLk.Lock()
+		defer id.resultsLk.Unlock()
+		return len(id.waiters[remote.ID()]) == 1
+	}, time.Second, time.Millisecond)
+
+	id.identified(remote.ID(), false)
+	assert.False(t, <-done)
+
+	// A peer stays identified if the exchange fails on another connection
+	id.identified(remote.ID(), true)
+	id.identified(remote.ID(), false)
+	assert.True(t, id.Wait(context.Background(), remote.ID(), time.Minute))
+
+	// Waiting is canceled with the context
+	id.forget(remote.ID())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, id.Wait(ctx, remote.ID(), time.Minute))
+	assert.Empty(t, id.waiters)
+}
+
+func TestIdentifier_Wait_identifiedBefore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := newTestHost(t)
+	remote := newTestHost(t)
+	require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+	assert.Eventually(t, func() bool {
+		_, err := h.Peerstore().Get(remote.ID(), "AgentVersion")
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The identify exchange completed before the Identifier subscribed to its events
+	id, err := NewIdentifier(ctx, h)
+	require.NoError(t, err)
+
+	start := time.Now()
+	assert.True(t, id.Wait(ctx, remote.ID(), time.Minute))
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,49 @@
+package start
+
+import (
+	"math"
+
+	"github.com/libp2p/go-libp2p"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/pbnjay/memory"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+// defaultFDs is the number of file descriptors that the resource manager uses if the limit of the process
+// is unknown or unlimited. It's also the minimum, so that Antares can always open enough connections.
+const defaultFDs = 4096
+
+// resourceLimits scales the default limits of the libp2p resource manager to the configured memory and file
+// descriptors. Unconfigured values default to the same resources that the resource manager would pick itself.
+func resourceLimits(conf config.ResourceManager) rcmgr.LimitConfig {
+	maxMemory := conf.MaxMemory
+	if maxMemory == 0 {
+		maxMemory = int64(memory.TotalMemory()) / 8
+	}
+
+	maxFDs := conf.MaxFileDescriptors
+	if maxFDs == 0 {
+		maxFDs = autoFDs(fdLimit())
+	}
+
+	limits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&limits)
+
+	return limits.Scale(maxMemory, maxFDs)
+}
+
+// autoFDs returns the number of file descriptors that the resource manager may use given the file descriptor
+// limit of the process. It leaves half of the limit to other uses, e.g., the database and HTTP clients.
+func autoFDs(limit uint64) int {
+	// Unknown or unlimited, e.g., RLIM_INFINITY
+	if limit == 0 || limit > math.MaxInt32 {
+		return defaultFDs
+	}
+
+	if fds := int(limit / 2); fds > defaultFDs {
+		return fds
+	}
+
+	return defaultFDs
+}
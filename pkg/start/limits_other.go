@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package start
+
+import "math"
+
+// fdLimit returns the maximum number of file descriptors that the process may open. There is no
+// such limit on other platforms, e.g., on Windows.
+func fdLimit() uint64 {
+	return math.MaxUint64
+}
@@ -0,0 +1,52 @@
+package start
+
+import (
+	"math"
+	"testing"
+
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+func TestResourceLimits(t *testing.T) {
+	small := resourceLimits(config.ResourceManager{MaxMemory: 256 << 20, MaxFileDescriptors: 512})
+	large := resourceLimits(config.ResourceManager{MaxMemory: 4 << 30, MaxFileDescriptors: 8192})
+
+	assert.Less(t, small.System.Conns, large.System.Conns)
+	assert.Less(t, small.System.Memory, large.System.Memory)
+	assert.Less(t, small.System.FD, large.System.FD)
+
+	// The default service limits of libp2p are included
+	assert.NotEmpty(t, large.Service)
+
+	auto := resourceLimits(config.ResourceManager{})
+	assert.Positive(t, auto.System.Conns)
+	assert.Positive(t, auto.System.FD)
+}
+
+func TestAutoFDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit uint64
+		want  int
+	}{
+		{name: "unknown", limit: 0, want: defaultFDs},
+		{name: "unlimited", limit: math.MaxUint64, want: defaultFDs},
+		{name: "unlimited darwin", limit: math.MaxInt64, want: defaultFDs},
+		{name: "small", limit: 1024, want: defaultFDs},
+		{name: "large", limit: 65536, want: 32768},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, autoFDs(tt.limit))
+		})
+	}
+
+	// The scaled limits must allow connections for the default
+	limits := rcmgr.DefaultLimits
+	scaled := limits.Scale(256<<20, autoFDs(math.MaxUint64))
+	assert.Positive(t, scaled.System.FD)
+	assert.Positive(t, scaled.System.Conns)
+}
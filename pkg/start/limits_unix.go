@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package start
+
+import (
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// fdLimit returns the maximum number of file descriptors that the process may open.
+// It returns zero if the limit can't be determined.
+func fdLimit() uint64 {
+	var l syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &l); err != nil {
+		log.WithError(err).Warnln("Could not determine file descriptor limit")
+		return 0
+	}
+	return uint64(l.Cur)
+}
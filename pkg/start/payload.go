@@ -8,9 +8,22 @@ import (
 	dag "github.com/ipfs/go-merkledag"
 	ft "github.com/ipfs/go-unixfs"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 )
 
+// PayloadClockSkew is the time that may pass between the creation of a payload and the start of its probe.
+// Payloads that were created earlier than that are considered stale.
+const PayloadClockSkew = 10 * time.Second
+
+var (
+	// ErrCorruptedContent is returned if retrieved content doesn't match its CID or carries an invalid signature.
+	ErrCorruptedContent = errors.New("corrupted content")
+
+	// ErrStaleContent is returned if retrieved content was signed by Antares but belongs to an earlier probe.
+	ErrStaleContent = errors.New("stale content")
+)
+
 // Payload is the underlying data that gets announced to the network
 type Payload struct {
 	Message   string
@@ -20,7 +33,8 @@ type Payload struct {
 }
 
 // NewPayload generates 100 bytes of random data and initializes a Payload
-// data structure. It's also signing the data for no reason.
+// data structure. The data is signed, so that content that is retrieved
+// from a target can be verified to originate from this Antares instance.
 func NewPayload(key crypto.PrivKey) (*Payload, error) {
 	buf := make([]byte, 100)
 	_, err := rand.Read(buf)
@@ -65,3 +79,53 @@ func (p *Payload) Bytes() ([]byte, error) {
 func (p *Payload) JsonBytes() ([]byte, error) {
 	return json.Marshal(p)
 }
+
+// ParsePayload parses the json representation of a payload.
+func ParsePayload(data []byte) (*Payload, error) {
+	var p Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, errors.Wrap(ErrCorruptedContent, err.Error())
+	}
+	return &p, nil
+}
+
+// ParsePayloadBlock extracts the payload from the raw data of the DAG node that Bytes produced.
+func ParsePayloadBlock(data []byte) (*Payload, error) {
+	node, err := dag.DecodeProtobuf(data)
+	if err != nil {
+		return nil, errors.Wrap(ErrCorruptedContent, err.Error())
+	}
+
+	fsNode, err := ft.FSNodeFromBytes(node.Data())
+	if err != nil {
+		return nil, errors.Wrap(ErrCorruptedContent, err.Error())
+	}
+
+	return ParsePayload(fsNode.Data())
+}
+
+// Verify checks that the payload was signed by the given peer and that it wasn't created before
+// the given time. A zero notBefore skips the latter check.
+func (p *Payload) Verify(signer peer.ID, notBefore time.Time) error {
+	key, err := signer.ExtractPublicKey()
+	if err != nil {
+		return errors.Wrap(err, "extract public key")
+	}
+
+	unsigned := *p
+	unsigned.Signature = nil
+	dat, err := json.Marshal(unsigned)
+	if err != nil {
+		return errors.Wrap(err, "marshal probe data")
+	}
+
+	if ok, err := key.Verify(dat, p.Signature); err != nil || !ok {
+		return errors.Wrap(ErrCorruptedContent, "invalid signature")
+	}
+
+	if p.Timestamp.Before(notBefore) {
+		return errors.Wrapf(ErrStaleContent, "payload created at %s", p.Timestamp)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package start
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPrivKey(t *testing.T) crypto.PrivKey {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// testStalePayload returns a correctly signed payload that was created an hour ago.
+func testStalePayload(t *testing.T, key crypto.PrivKey) *Payload {
+	p, err := NewPayload(key)
+	require.NoError(t, err)
+
+	p.Timestamp = p.Timestamp.Add(-time.Hour)
+	p.Signature = nil
+	dat, err := json.Marshal(p)
+	require.NoError(t, err)
+	p.Signature, err = key.Sign(dat)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestPayload_Verify(t *testing.T) {
+	key := testPrivKey(t)
+	signer, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	other, err := peer.IDFromPrivateKey(testPrivKey(t))
+	require.NoError(t, err)
+
+	p, err := NewPayload(key)
+	require.NoError(t, err)
+
+	// Verification must also succeed after a round trip through the different encodings
+	data, err := p.Bytes()
+	require.NoError(t, err)
+	parsed, err := ParsePayloadBlock(data)
+	require.NoError(t, err)
+
+	start := time.Now()
+	assert.NoError(t, parsed.Verify(signer, start.Add(-PayloadClockSkew)))
+	assert.NoError(t, parsed.Verify(signer, time.Time{}))
+	assert.ErrorIs(t, parsed.Verify(other, time.Time{}), ErrCorruptedContent)
+	assert.ErrorIs(t, testStalePayload(t, key).Verify(signer, start.Add(-PayloadClockSkew)), ErrStaleContent)
+
+	parsed.Random[0] ^= 0xff
+	assert.ErrorIs(t, parsed.Verify(signer, time.Time{}), ErrCorruptedContent)
+
+	_, err = ParsePayloadBlock([]byte("garbage"))
+	assert.ErrorIs(t, err, ErrCorruptedContent)
+}
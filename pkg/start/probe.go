@@ -2,27 +2,392 @@ package start
 
 import (
 	"context"
-	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/amit7itz/goset"
 	"github.com/cenkalti/backoff/v4"
-	"github.com/dennis-tra/antares/pkg/db"
+	"github.com/dennis-tra/antares/pkg/maxmind"
+	"github.com/dennis-tra/antares/pkg/metrics"
 	"github.com/dennis-tra/antares/pkg/models"
+	"github.com/dennis-tra/antares/pkg/sink"
 	"github.com/dennis-tra/antares/pkg/utils"
+	pb "github.com/ipfs/go-bitswap/message/pb"
 	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
-	"time"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 )
 
+// wantTypes maps Bitswap want types to their database representation.
+var wantTypes = map[pb.Message_Wantlist_WantType]string{
+	pb.Message_Wantlist_Block: models.WantTypeBlock,
+	pb.Message_Wantlist_Have:  models.WantTypeHave,
+}
+
+// connDirections maps connection directions to their database representation.
+var connDirections = map[network.Direction]string{
+	network.DirInbound:  models.ConnDirectionInbound,
+	network.DirOutbound: models.ConnDirectionOutbound,
+}
+
+// PingTimeout is the maximum time that a probe waits for the libp2p ping of a peer that it records.
+var PingTimeout = 5 * time.Second
+
 type Probe interface {
 	run(ctx context.Context)
 	logEntry() *log.Entry
 	wait()
 }
 
+// newSighting gathers all information about the given peer from the peer store and the open connections.
+// The multi addresses are resolved to their countries, continents and autonomous system numbers.
+func newSighting(ctx context.Context, h host.Host, mmc *maxmind.Client, probe *sink.Probe, peerID peer.ID) *sink.Sighting {
+	ps := h.Peerstore()
+
+	protocols, err := ps.GetProtocols(peerID)
+	if err != nil {
+		protocols = nil
+	}
+
+	var agentVersion string
+	if val, err := ps.Get(peerID, "AgentVersion"); err == nil {
+		agentVersion = val.(string)
+	}
+
+	conns := h.Network().ConnsToPeer(peerID)
+
+	maddrSet := map[string]ma.Multiaddr{}
+	for _, maddr := range ps.Addrs(peerID) {
+		maddrSet[maddr.String()] = maddr
+	}
+	for _, conn := range conns {
+		maddr := conn.RemoteMultiaddr()
+		maddrSet[maddr.String()] = maddr
+	}
+
+	maddrStrs := []string{}
+	ipAddressesSet := goset.NewSet[string]()
+	countriesSet := goset.NewSet[string]()
+	continentsSet := goset.NewSet[string]()
+	asnsSet := goset.NewSet[int64]()
+
+	for maddrStr, maddr := range maddrSet {
+		if utils.IsRelayedMaddr(maddr) || !manet.IsPublicAddr(maddr) {
+			continue
+		}
+
+		maddrStrs = append(maddrStrs, maddrStr)
+		maddrInfos, err := mmc.MaddrInfo(ctx, maddr)
+		if err != nil {
+			continue
+		}
+
+		for ipAddress, maddrInfo := range maddrInfos {
+			ipAddressesSet.Add(ipAddress)
+			countriesSet.Add(maddrInfo.Country)
+			continentsSet.Add(maddrInfo.Continent)
+			asnsSet.Add(int64(maddrInfo.ASN))
+		}
+	}
+
+	ipAddressesSet.Discard("")
+	countriesSet.Discard("")
+	continentsSet.Discard("")
+	asnsSet.Discard(0)
+
+	ipAddresses := ipAddressesSet.Items()
+	countries := countriesSet.Items()
+	continents := continentsSet.Items()
+	asns := asnsSet.Items()
+
+	sort.Strings(maddrStrs)
+	sort.Strings(ipAddresses)
+	sort.Strings(countries)
+	sort.Strings(continents)
+	sort.Slice(asns, func(i, j int) bool { return asns[i] < asns[j] })
+
+	sighting := &sink.Sighting{
+		Probe:          probe,
+		PeerID:         peerID.String(),
+		AgentVersion:   agentVersion,
+		Protocols:      protocols,
+		MultiAddresses: maddrStrs,
+		IPAddresses:    ipAddresses,
+		Countries:      countries,
+		Continents:     continents,
+		ASNs:           asns,
+		SeenAt:         time.Now(),
+	}
+
+	if conn := sightingConn(conns); conn != nil {
+		state := conn.ConnState()
+		sighting.Transport = utils.MaddrTransport(conn.RemoteMultiaddr())
+		sighting.IPFamily = utils.MaddrIPFamily(conn.RemoteMultiaddr())
+		sighting.Direction = connDirections[conn.Stat().Direction]
+		sighting.SecurityProtocol = state.Security
+		sighting.Muxer = state.StreamMultiplexer
+		opened := conn.Stat().Opened
+		sighting.ConnOpenedAt = &opened
+		sighting.Relayed = utils.IsRelayedMaddr(conn.RemoteMultiaddr())
+	}
+
+	return sighting
+}
+
+// pingPeer measures the round trip time to the given peer with a libp2p ping. It returns zero if we're not connected
+// to the peer, so that it isn't dialed again, or if the ping didn't succeed within the PingTimeout.
+func pingPeer(ctx context.Context, h host.Host, peerID peer.ID) time.Duration {
+	if h.Network().Connectedness(peerID) != network.Connected {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, PingTimeout)
+	defer cancel()
+
+	// The channel is closed without a result if the context expires first
+	res, more := <-ping.Ping(ctx, h, peerID)
+	if !more {
+		return 0
+	} else if res.Error != nil {
+		log.WithError(res.Error).WithField("peerID", peerID).Debugln("Could not ping peer")
+		return 0
+	}
+
+	return res.RTT
+}
+
+// sightingConn returns the connection on which a peer was most likely observed. It prefers connections like libp2p
+// does when it opens new streams: direct over relayed connections, then the one with the most streams, and then the
+// newest. It returns nil if there is no connection to the peer.
+func sightingConn(conns []network.Conn) network.Conn {
+	var best network.Conn
+	for _, conn := range conns {
+		if best == nil || isBetterConn(conn, best) {
+			best = conn
+		}
+	}
+	return best
+}
+
+// isBetterConn returns true if a is a better connection than b. See sightingConn.
+func isBetterConn(a network.Conn, b network.Conn) bool {
+	aRelayed, bRelayed := utils.IsRelayedMaddr(a.RemoteMultiaddr()), utils.IsRelayedMaddr(b.RemoteMultiaddr())
+	if aRelayed != bRelayed {
+		return !aRelayed
+	}
+
+	aStreams, bStreams := len(a.GetStreams()), len(b.GetStreams())
+	if aStreams != bStreams {
+		return aStreams > bStreams
+	}
+
+	return a.Stat().Opened.After(b.Stat().Opened)
+}
+
+// trackLookup gathers the information about the peer that looked up the CID of the given probe and writes it to the sink.
+func trackLookup(ctx context.Context, h host.Host, snk sink.Sink, logEntry *log.Entry, probe *sink.Probe, lookup *Lookup) {
+	logEntry = logEntry.WithField("peerID", lookup.PeerID).WithField("lookupType", lookup.Type)
+	logEntry.Infoln("Tracking peer that looked up cid")
+
+	var agentVersion string
+	if val, err := h.Peerstore().Get(lookup.PeerID, "AgentVersion"); err == nil {
+		agentVersion = val.(string)
+	}
+
+	maddrSet := map[string]struct{}{}
+	for _, maddr := range h.Peerstore().Addrs(lookup.PeerID) {
+		maddrSet[maddr.String()] = struct{}{}
+	}
+	for _, conn := range h.Network().ConnsToPeer(lookup.PeerID) {
+		maddrSet[conn.RemoteMultiaddr().String()] = struct{}{}
+	}
+
+	maddrStrs := make([]string, 0, len(maddrSet))
+	for maddrStr := range maddrSet {
+		maddrStrs = append(maddrStrs, maddrStr)
+	}
+	sort.Strings(maddrStrs)
+
+	l := &sink.Lookup{
+		Probe:          probe,
+		PeerID:         lookup.PeerID.String(),
+		AgentVersion:   agentVersion,
+		MultiAddresses: maddrStrs,
+		Type:           lookupTypes[lookup.Type],
+		SeenAt:         lookup.SeenAt,
+	}
+
+	if err := snk.TrackLookup(ctx, l); err != nil {
+		logEntry.WithError(err).Warnln("Error tracking lookup")
+	}
+}
+
+// runProbes starts probes of the given target at its rate until the given context is canceled. Up to the
+// concurrency of the target are in flight at the same time as long as the given slots, which are shared by
+// all targets, allow it. It returns after all probes have finished.
+func runProbes(ctx context.Context, target Target, slots probeSlots, logEntry *log.Entry, probeTarget func(context.Context) error) {
+	if target.Rate() <= 0 {
+		logEntry.WithField("rate", target.Rate()).Errorln("Not probing target with a rate that's not positive")
+		return
+	}
+
+	throttle := NewThrottle(1, target.Rate())
+	defer throttle.Stop()
+
+	inFlight := newProbeSlots(target.Concurrency())
+
+	var probesWg sync.WaitGroup
+	defer probesWg.Wait()
+
+	for {
+		// Giving cancelled context precedence
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		logEntry.WithField("rate", target.Rate()).Infoln("Checking probe lease...")
+		select {
+		case <-ctx.Done():
+			return
+		case <-throttle.C:
+		}
+
+		// Wait until the target and all targets together allow another probe
+		if !inFlight.acquire(ctx) {
+			return
+		} else if !slots.acquire(ctx) {
+			inFlight.release()
+			return
+		}
+
+		probesWg.Add(1)
+		go func() {
+			defer probesWg.Done()
+			defer inFlight.release()
+			defer slots.release()
+
+			if err := probeTarget(ctx); err != nil && !utils.IsContextErr(err) {
+				logEntry.WithError(err).Warnln("Error probing target")
+			}
+		}()
+	}
+}
+
+// probePeers protects the connections to the peers that take part in an in-flight probe from being closed by the
+// connection manager. Otherwise, the connection manager could, e.g., interrupt the Bitswap exchange with a peer that
+// requested the CID of the probe. It must only be used by the go-routine of the probe.
+type probePeers struct {
+	cm    connmgr.ConnManager
+	tag   string
+	peers map[peer.ID]struct{}
+}
+
+// newProbePeers initializes the protection of the peers that take part in the probe of the given CID.
+func newProbePeers(h host.Host, c cid.Cid) *probePeers {
+	return &probePeers{
+		cm:    h.ConnManager(),
+		tag:   "antares-probe-" + c.String(),
+		peers: map[peer.ID]struct{}{},
+	}
+}
+
+// protect protects the connections to the given peer until the probe has finished.
+func (pp *probePeers) protect(peerID peer.ID) {
+	pp.cm.Protect(peerID, pp.tag)
+	pp.peers[peerID] = struct{}{}
+}
+
+// unprotectAll removes the protection of all peers after the probe has finished. A peer may
+// stay protected if it takes part in other probes.
+func (pp *probePeers) unprotectAll() {
+	for peerID := range pp.peers {
+		pp.cm.Unprotect(peerID, pp.tag)
+	}
+	pp.peers = map[peer.ID]struct{}{}
+}
+
+// retrievalKey is the context key under which the retrieval is passed to the target operation.
+type retrievalKey struct{}
+
+// A retrieval carries the information that a target operation needs to verify the content it retrieves and
+// collects the details of the responses that the operation got. The operation runs concurrently to the probe.
+type retrieval struct {
+	// The start of the probe. Content that was created earlier is stale.
+	start time.Time
+
+	mu       sync.Mutex
+	response *sink.HTTPResponse
+}
+
+// newRetrieval initializes a retrieval of the content of the given probe.
+func newRetrieval(probe *sink.Probe) *retrieval {
+	return &retrieval{start: probe.StartedAt}
+}
+
+// Response returns the last HTTP response that the target operation recorded or nil if there was none.
+func (r *retrieval) Response() *sink.HTTPResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.response
+}
+
+// withRetrieval returns a copy of the given context that carries the given retrieval.
+func withRetrieval(ctx context.Context, r *retrieval) context.Context {
+	return context.WithValue(ctx, retrievalKey{}, r)
+}
+
+// probeStart returns the start of the probe that the given context belongs to or the zero time if unknown.
+func probeStart(ctx context.Context) time.Time {
+	if r, ok := ctx.Value(retrievalKey{}).(*retrieval); ok {
+		return r.start
+	}
+	return time.Time{}
+}
+
+// recordResponse stores the given HTTP response in the retrieval of the given context. It replaces the responses
+// of previous attempts of the operation.
+func recordResponse(ctx context.Context, resp *sink.HTTPResponse) {
+	r, ok := ctx.Value(retrievalKey{}).(*retrieval)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.response = resp
+}
+
+// verificationOf determines the verification result of the retrieved content from the error of the probe
+// operation. It returns an empty string if the target doesn't verify the content it retrieves.
+func verificationOf(target Target, err error) string {
+	switch {
+	case errors.Is(err, ErrCorruptedContent):
+		return models.ProbeVerificationCorrupted
+	case errors.Is(err, ErrStaleContent):
+		return models.ProbeVerificationStale
+	case err != nil:
+		return ""
+	}
+
+	if vt, ok := target.(VerifyingTarget); ok && vt.VerifiesContent() {
+		return models.ProbeVerificationValid
+	}
+
+	return ""
+}
+
 func backoffWrap(ctx context.Context, c cid.Cid, fn func(context.Context, cid.Cid) error) backoff.Operation {
 	return func() error {
 		return fn(ctx, c)
@@ -43,103 +408,140 @@ func cleanupProbe(ctx context.Context, logEntry *log.Entry, target Target, cid c
 	logEntry.Warnln("Target does not support cleanup")
 }
 
-func insertModel(ctx context.Context, dryRun bool, dbc *db.Client, logEntry *log.Entry, protocols []string,
-	agentVersion string, peerID peer.ID, ipAddresses []string, maddrStrs []string,
-	countries []string, continents []string, asns []int64, targetType string, targetName string) error {
+// probeResult captures the outcome and the timings of a single probe run. Durations
+// that are zero were not measured.
+type probeResult struct {
+	outcome           string
+	verification      string
+	err               error
+	provideDuration   time.Duration
+	operationDuration time.Duration
+	firstWantLatency  time.Duration
+	firstWantSeen     bool
+	httpResponse      *sink.HTTPResponse
+}
 
-	if dryRun {
-		logEntry.Infoln("Skipping database interaction due to --dry-run flag")
+// setOutcome determines the outcome of a probe after its timeout context has expired. opErr is the channel on
+// which the probe operation delivers its error if it failed permanently.
+func (r *probeResult) setOutcome(ctx context.Context, opErr <-chan error) {
+	select {
+	case err := <-opErr:
+		r.outcome = models.ProbeOutcomeError
+		r.err = err
+		return
+	default:
+	}
 
-		logEntry.Infoln("Tracked the following peer:")
-		logEntry.Infoln("  PeerID", peerID.String())
-		logEntry.Infoln("  AgentVersion", agentVersion)
-		logEntry.Infoln("  Protocols", protocols)
-		for i, protocol := range protocols {
-			logEntry.Infof("    [%d] %s\n", i, protocol)
-		}
-		logEntry.Infoln("  MultiAddresses", maddrStrs)
-		for i, maddrStr := range maddrStrs {
-			logEntry.Infof("    [%d] %s\n", i, maddrStr)
-		}
-		logEntry.Infoln("  IPAddresses", ipAddresses)
-		for i, ipAddress := range ipAddresses {
-			logEntry.Infof("    [%d] %s\n", i, ipAddress)
-		}
-		logEntry.Infoln("  Countries", countries)
-		logEntry.Infoln("  Continents", continents)
-		logEntry.Infoln("  ASNs", asns)
-		logEntry.Infoln("  TargetType", targetType)
-		logEntry.Infoln("  TargetName", targetName)
+	if ctx.Err() != nil {
+		r.outcome = models.ProbeOutcomeCanceled
+	} else {
+		r.outcome = models.ProbeOutcomeTimeout
+	}
+}
 
-		return nil
+// setFirstWantLatency records the time between the start of the probe operation and the given want if it's
+// the first want that was seen after the operation started. Earlier wants aren't caused by the operation.
+func (r *probeResult) setFirstWantLatency(want *Want, opStart time.Time) {
+	if r.firstWantSeen || !want.SeenAt.After(opStart) {
+		return
 	}
+	r.firstWantSeen = true
+	r.firstWantLatency = want.SeenAt.Sub(opStart)
+}
 
-	txn, err := dbc.BeginTx(ctx, nil)
-	if err != nil {
-		return errors.Wrap(err, "begin txn")
+// setOperationDuration takes the duration from the given channel if the operation has already completed.
+func (r *probeResult) setOperationDuration(opDuration <-chan time.Duration) {
+	select {
+	case r.operationDuration = <-opDuration:
+	default:
 	}
-	defer func() {
-		if err = txn.Rollback(); err != nil && err != sql.ErrTxDone {
-			log.WithError(err).Warnln("Error rolling back transaction")
-		}
-	}()
-
-	dbPeer, err := models.Peers(qm.Expr(
-		models.PeerWhere.MultiHash.EQ(peerID.String()),
-		models.PeerWhere.TargetName.EQ(targetName),
-	)).One(ctx, txn)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return errors.Wrap(err, "query peer from db")
-	}
-
-	if dbPeer == nil {
-		dbPeer = &models.Peer{
-			MultiHash:      peerID.String(),
-			AgentVersion:   null.NewString(agentVersion, agentVersion != ""),
-			Protocols:      protocols,
-			MultiAddresses: maddrStrs,
-			IPAddresses:    ipAddresses,
-			Countries:      countries,
-			Continents:     continents,
-			Asns:           asns,
-			TargetType:     targetType,
-			TargetName:     targetName,
-			LastSeenAt:     time.Now(),
-		}
-		if err = dbPeer.Insert(ctx, txn, boil.Infer()); err != nil {
-			return errors.Wrap(err, "insert db peer")
-		}
-	} else {
-		if agentVersion != "" {
-			dbPeer.AgentVersion = null.StringFrom(agentVersion)
-		}
-		if len(protocols) != 0 {
-			dbPeer.Protocols = protocols
-		}
-		if len(maddrStrs) != 0 {
-			dbPeer.MultiAddresses = maddrStrs
-		}
-		if len(ipAddresses) != 0 {
-			dbPeer.IPAddresses = ipAddresses
-		}
-		if len(countries) != 0 {
-			dbPeer.Countries = countries
-		}
-		if len(continents) != 0 {
-			dbPeer.Continents = continents
-		}
-		if len(asns) != 0 {
-			dbPeer.Asns = asns
-		}
-		dbPeer.LastSeenAt = time.Now()
-		if _, err = dbPeer.Update(ctx, txn, boil.Infer()); err != nil {
-			return errors.Wrap(err, "insert db peer")
+}
+
+// setVerification takes the verification result from the given channel if the operation has already completed.
+func (r *probeResult) setVerification(verification <-chan string) {
+	select {
+	case r.verification = <-verification:
+	default:
+	}
+}
+
+// startProbe records the start of a new probe run in the given sink.
+func startProbe(ctx context.Context, snk sink.Sink, target Target, c cid.Cid) (*sink.Probe, error) {
+	probe := &sink.Probe{
+		TargetType: target.Type(),
+		TargetName: target.Name(),
+		CID:        c.String(),
+		StartedAt:  time.Now(),
+	}
+	if err := snk.StartProbe(ctx, probe); err != nil {
+		return nil, err
+	}
+
+	return probe, nil
+}
+
+// finishProbe records the measured timings of the given probe run in prometheus and writes its end and outcome
+// to the given sink. The sink is not bound to the given context, so that the outcome is also written if Antares
+// is being stopped.
+func finishProbe(ctx context.Context, snk sink.Sink, logEntry *log.Entry, probe *sink.Probe, res *probeResult) {
+	if res.provideDuration != 0 {
+		stats.Record(ctx, metrics.ProvideDuration.M(millis(res.provideDuration)))
+	}
+	if res.operationDuration != 0 {
+		stats.Record(ctx, metrics.OperationDuration.M(millis(res.operationDuration)))
+	}
+	if res.firstWantSeen {
+		stats.Record(ctx, metrics.FirstWantLatency.M(millis(res.firstWantLatency)))
+	}
+	if res.verification != "" {
+		vCtx, err := tag.New(ctx, tag.Upsert(metrics.KeyVerification, res.verification))
+		if err == nil {
+			stats.Record(vCtx, metrics.VerificationCount.M(1))
 		}
 	}
 
-	if err = txn.Commit(); err != nil {
-		return errors.Wrap(err, "commit txn")
+	probe.EndedAt = time.Now()
+	probe.Outcome = res.outcome
+	probe.Verification = res.verification
+	probe.ProvideDuration = res.provideDuration
+	probe.OperationDuration = res.operationDuration
+	probe.FirstWantLatency = res.firstWantLatency
+	probe.HTTPResponse = res.httpResponse
+	if res.err != nil {
+		probe.Error = res.err.Error()
 	}
 
-	return nil
+	logEntry = logEntry.WithFields(log.Fields{
+		"outcome":           res.outcome,
+		"verification":      res.verification,
+		"provideDuration":   res.provideDuration,
+		"operationDuration": res.operationDuration,
+		"firstWantLatency":  res.firstWantLatency,
+	})
+	if res.err != nil {
+		logEntry = logEntry.WithError(res.err)
+	}
+	logEntry.Infoln("Finished probe")
+
+	if err := snk.FinishProbe(context.Background(), probe); err != nil {
+		logEntry.WithError(err).Warnln("Error finishing probe")
+	}
+}
+
+// millis converts the given duration to fractional milliseconds.
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// finishSighting writes the Bitswap interactions that happened after the given sighting was tracked to the given
+// sink. The want must not be modified concurrently anymore, i.e., its CID must have been unregistered from the tracer.
+func finishSighting(snk sink.Sink, logEntry *log.Entry, sighting *sink.Sighting, want *Want) {
+	if want != nil && sighting.Want != nil {
+		sighting.Want.CanceledAt = want.CanceledAt
+		sighting.Want.BlockSentAt = want.BlockSentAt
+	}
+
+	if err := snk.FinishSighting(context.Background(), sighting); err != nil {
+		logEntry.WithError(err).WithField("peerID", sighting.PeerID).Warnln("Error finishing sighting")
+	}
 }
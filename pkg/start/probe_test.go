@@ -0,0 +1,171 @@
+package start
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/models"
+	"github.com/dennis-tra/antares/pkg/sink"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+func TestProbePeers(t *testing.T) {
+	cm, err := connmgr.NewConnManager(1, 2)
+	require.NoError(t, err)
+
+	h, err := libp2p.New(libp2p.NoListenAddrs, libp2p.ConnectionManager(cm))
+	require.NoError(t, err)
+	defer h.Close()
+
+	peerID := peer.ID("peer")
+	first := newProbePeers(h, blocks.NewBlock([]byte("first")).Cid())
+	second := newProbePeers(h, blocks.NewBlock([]byte("second")).Cid())
+
+	first.protect(peerID)
+	second.protect(peerID)
+	assert.True(t, cm.IsProtected(peerID, ""))
+
+	// The peer stays protected while it takes part in another probe
+	first.unprotectAll()
+	assert.True(t, cm.IsProtected(peerID, ""))
+
+	second.unprotectAll()
+	assert.False(t, cm.IsProtected(peerID, ""))
+}
+
+func TestNewSighting_conn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	h := newTestHost(t)
+	remote := newTestHost(t)
+	require.NoError(t, remote.Connect(ctx, peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}))
+
+	// Loopback addresses aren't resolved, so no Maxmind client is needed
+	sighting := newSighting(ctx, h, nil, &sink.Probe{}, remote.ID())
+	assert.Equal(t, utils.TransportTCP, sighting.Transport)
+	assert.Equal(t, utils.IPFamilyIPv4, sighting.IPFamily)
+	assert.Equal(t, models.ConnDirectionInbound, sighting.Direction)
+	assert.NotEmpty(t, sighting.SecurityProtocol)
+	assert.NotEmpty(t, sighting.Muxer)
+	require.NotNil(t, sighting.ConnOpenedAt)
+	assert.WithinDuration(t, time.Now(), *sighting.ConnOpenedAt, 10*time.Second)
+	assert.False(t, sighting.Relayed)
+
+	sighting = newSighting(ctx, h, nil, &sink.Probe{}, newTestHost(t).ID())
+	assert.Empty(t, sighting.Direction)
+	assert.Nil(t, sighting.ConnOpenedAt)
+}
+
+func TestPingPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	h := newTestHost(t)
+	remote := newTestHost(t)
+	require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+
+	assert.Positive(t, pingPeer(ctx, h, remote.ID()))
+
+	// Peers that we aren't connected to aren't dialed
+	other := newTestHost(t)
+	h.Peerstore().AddAddrs(other.ID(), other.Addrs(), time.Minute)
+	assert.Zero(t, pingPeer(ctx, h, other.ID()))
+	assert.Empty(t, h.Network().ConnsToPeer(other.ID()))
+}
+
+type testTarget struct {
+	probeSettings
+}
+
+func (t testTarget) Name() string { return "test" }
+func (t testTarget) Type() string { return "test" }
+
+func TestRunProbes(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		slots       int
+		want        int64
+	}{
+		{name: "sequential", concurrency: 0, slots: 0, want: 1},
+		{name: "concurrent", concurrency: 3, slots: 0, want: 3},
+		{name: "capped", concurrency: 3, slots: 2, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			target := testTarget{newProbeSettings(config.Probe{Rate: time.Millisecond, Concurrency: tt.concurrency}, config.Probe{})}
+
+			var inFlight, maxInFlight, started int64
+			probeTarget := func(ctx context.Context) error {
+				n := atomic.AddInt64(&inFlight, 1)
+				defer atomic.AddInt64(&inFlight, -1)
+				for {
+					peak := atomic.LoadInt64(&maxInFlight)
+					if n <= peak || atomic.CompareAndSwapInt64(&maxInFlight, peak, n) {
+						break
+					}
+				}
+				atomic.AddInt64(&started, 1)
+
+				// Probes only finish once Antares is stopped, like a slow target
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			done := make(chan struct{})
+			go func() {
+				runProbes(ctx, target, newProbeSlots(tt.slots), log.WithField("test", tt.name), probeTarget)
+				close(done)
+			}()
+
+			assert.Eventually(t, func() bool { return atomic.LoadInt64(&started) == tt.want }, time.Second, time.Millisecond)
+			time.Sleep(20 * time.Millisecond)
+			assert.Equal(t, tt.want, atomic.LoadInt64(&maxInFlight))
+
+			cancel()
+			<-done
+			assert.Zero(t, atomic.LoadInt64(&inFlight))
+		})
+	}
+}
+
+func TestRunProbes_invalidRate(t *testing.T) {
+	target := testTarget{newProbeSettings(config.Probe{Rate: -time.Minute}, config.Probe{})}
+
+	probeTarget := func(ctx context.Context) error {
+		t.Error("probed target with negative rate")
+		return nil
+	}
+
+	// Must return right away instead of panicking
+	runProbes(context.Background(), target, newProbeSlots(0), log.WithField("test", t.Name()), probeTarget)
+}
+
+func TestProbeResult_setFirstWantLatency(t *testing.T) {
+	opStart := time.Now()
+
+	res := &probeResult{}
+	res.setFirstWantLatency(&Want{SeenAt: opStart.Add(-time.Second)}, opStart)
+	assert.False(t, res.firstWantSeen)
+
+	// The first want after the operation started counts even though an earlier want was tracked before
+	res.setFirstWantLatency(&Want{SeenAt: opStart.Add(2 * time.Second)}, opStart)
+	res.setFirstWantLatency(&Want{SeenAt: opStart.Add(3 * time.Second)}, opStart)
+	assert.True(t, res.firstWantSeen)
+	assert.Equal(t, 2*time.Second, res.firstWantLatency)
+}
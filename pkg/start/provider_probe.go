@@ -2,7 +2,8 @@ package start
 
 import (
 	"context"
-	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/tag"
@@ -12,33 +13,33 @@ import (
 
 	"github.com/dennis-tra/antares/pkg/utils"
 
-	"github.com/amit7itz/goset"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/dennis-tra/antares/pkg/config"
-	"github.com/dennis-tra/antares/pkg/db"
 	"github.com/dennis-tra/antares/pkg/maxmind"
+	"github.com/dennis-tra/antares/pkg/models"
+	"github.com/dennis-tra/antares/pkg/sink"
 	blocks "github.com/ipfs/go-block-format"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/libp2p/go-libp2p/core/peer"
-	ma "github.com/multiformats/go-multiaddr"
-	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
 type PinProbe struct {
 	host       host.Host
-	dbc        *db.Client
+	sink       sink.Sink
 	mmc        *maxmind.Client
 	config     *config.Config
 	dht        *kaddht.IpfsDHT
 	bstore     blockstore.Blockstore
 	tracer     *Tracer
+	honeypot   *Honeypot
+	identifier *Identifier
 	target     PinTarget
 	probeCount int64
 	trackCount int64
+	slots      probeSlots
 	done       chan struct{}
 }
 
@@ -51,37 +52,11 @@ func (p *PinProbe) run(ctx context.Context) {
 		return
 	}
 
-	// Only request
-	throttle := NewThrottle(1, p.target.Rate())
-	defer throttle.Stop()
-
-	for {
-		// Giving cancelled context precedence
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
-		p.logEntry().WithField("rate", p.target.Rate()).Infoln("Checking probe lease...")
-		select {
-		case <-ctx.Done():
-			return
-		case <-throttle.C:
-		}
-
-		err := p.probeTarget(ctx)
-		if utils.IsContextErr(err) {
-			return
-		} else if err != nil {
-			p.logEntry().WithError(err).Warnln("Error probing target")
-		}
-	}
+	runProbes(ctx, p.target, p.slots, p.logEntry(), p.probeTarget)
 }
 
 func (p *PinProbe) probeTarget(ctx context.Context) error {
-	p.probeCount += 1
-	stats.Record(ctx, metrics.ProbeCount.M(p.probeCount))
+	stats.Record(ctx, metrics.ProbeCount.M(atomic.AddInt64(&p.probeCount, 1)))
 
 	block, teardown, err := p.generateContent(ctx)
 	defer teardown()
@@ -90,37 +65,127 @@ func (p *PinProbe) probeTarget(ctx context.Context) error {
 	}
 	logEntry := p.logEntry().WithField("cid", block.Cid())
 
+	probe, err := startProbe(ctx, p.sink, p.target, block.Cid())
+	if err != nil {
+		return errors.Wrap(err, "start probe")
+	}
+
 	logEntry.Infoln("Registering cid with tracer")
-	chPeerID := p.tracer.Register(block.Cid())
+	chWant := p.tracer.Register(block.Cid())
 	defer p.tracer.Unregister(block.Cid())
 
+	logEntry.Infoln("Registering cid with honeypot")
+	chLookup := p.honeypot.Register(block.Cid())
+	defer p.honeypot.Unregister(block.Cid())
+
+	res := &probeResult{}
+	defer finishProbe(ctx, p.sink, logEntry, probe, res)
+
+	peers := newProbePeers(p.host, block.Cid())
+	defer peers.unprotectAll()
+
 	logEntry.Infoln("Providing cid in the dht")
+	provideStart := time.Now()
 	err = p.dht.Provide(ctx, block.Cid(), true)
+	res.provideDuration = time.Since(provideStart)
 	if err != nil {
+		res.outcome = models.ProbeOutcomeError
+		res.err = err
 		return errors.Wrap(err, "dht provide content")
 	}
 
 	tCtx, cancel := context.WithTimeout(ctx, p.target.Timeout())
 	defer cancel()
+
+	opErr := make(chan error, 1)
+	opDuration := make(chan time.Duration, 1)
+	opVerification := make(chan string, 1)
+	rtv := newRetrieval(probe)
+	opStart := time.Now()
 	go func() {
 		logEntry.Infoln("Starting probe operation")
 
-		op := backoffWrap(tCtx, block.Cid(), p.target.Operation)
+		op := backoffWrap(withRetrieval(tCtx, rtv), block.Cid(), p.target.Operation)
 		bo := p.target.Backoff(tCtx)
 
-		if err = backoff.RetryNotify(op, bo, p.notify); err != nil && !utils.IsContextErr(err) {
+		err := backoff.RetryNotify(op, bo, p.notify)
+		if !utils.IsContextErr(err) {
+			opVerification <- verificationOf(p.target, err)
+		}
+
+		if err != nil && !utils.IsContextErr(err) {
 			logEntry.Infoln("Probe operation failed")
+			opErr <- err
 			cancel()
+		} else if err == nil {
+			opDuration <- time.Since(opStart)
 		}
 	}()
-	defer cleanupProbe(tCtx, logEntry, p.target, block.Cid())
-
-	select {
-	case peerID := <-chPeerID:
-		logEntry.WithField("peerID", peerID).Infoln("Tracking peer that requested cid")
-		return p.trackPeer(ctx, peerID)
-	case <-tCtx.Done():
-		return nil
+	// Use the parent context for the clean-up, so that it also runs after the probe window has expired.
+	defer cleanupProbe(ctx, logEntry, p.target, block.Cid())
+
+	// Track every distinct peer that wants the CID until the probe window expires. Peers are tracked concurrently,
+	// so that waiting for the identify exchange with one peer doesn't hold up the wants of others.
+	trackedPeers := 0
+	sightings := map[*Want]*sink.Sighting{}
+	var (
+		sightingsLk sync.Mutex
+		tracking    sync.WaitGroup
+	)
+	defer tracking.Wait()
+	for {
+		select {
+		case want, more := <-chWant:
+			if !more {
+				return nil
+			}
+
+			res.setFirstWantLatency(want, opStart)
+			trackedPeers += 1
+			peers.protect(want.PeerID)
+
+			tracking.Add(1)
+			go func(want *Want) {
+				defer tracking.Done()
+
+				logEntry.WithField("peerID", want.PeerID).Infoln("Tracking peer that requested cid")
+				sighting, err := p.trackPeer(ctx, probe, want)
+				if err != nil {
+					logEntry.WithError(err).WithField("peerID", want.PeerID).Warnln("Error tracking peer")
+					return
+				}
+
+				sightingsLk.Lock()
+				sightings[want] = sighting
+				sightingsLk.Unlock()
+			}(want)
+		case lookup, more := <-chLookup:
+			if !more {
+				chLookup = nil
+				continue
+			}
+			peers.protect(lookup.PeerID)
+			trackLookup(ctx, p.host, p.sink, logEntry, probe, lookup)
+		case <-tCtx.Done():
+			res.setOperationDuration(opDuration)
+			res.setVerification(opVerification)
+			res.httpResponse = rtv.Response()
+			if trackedPeers > 0 {
+				res.outcome = models.ProbeOutcomeSuccess
+			} else {
+				res.setOutcome(ctx, opErr)
+			}
+			logEntry.WithField("peers", trackedPeers).Infoln("Probe window expired")
+
+			// Stop the tracer from updating the wants, so that we can persist their final state.
+			p.tracer.Unregister(block.Cid())
+			tracking.Wait()
+			for want, sighting := range sightings {
+				finishSighting(p.sink, logEntry, sighting, want)
+			}
+
+			return nil
+		}
 	}
 }
 
@@ -160,73 +225,29 @@ func (p *PinProbe) generateContent(ctx context.Context) (*blocks.BasicBlock, fun
 	}, nil
 }
 
-func (p *PinProbe) trackPeer(ctx context.Context, peerID peer.ID) error {
-	p.trackCount += 1
-	stats.Record(ctx, metrics.TrackCount.M(p.trackCount))
-
-	ps := p.host.Peerstore()
-
-	protocols, err := ps.GetProtocols(peerID)
-	if err != nil {
-		protocols = nil
-	}
-
-	var agentVersion string
-	if val, err := ps.Get(peerID, "AgentVersion"); err == nil {
-		agentVersion = val.(string)
+// trackPeer records the peer that requested the CID after the identify exchange with it has completed. It's
+// called concurrently for all peers of a probe.
+func (p *PinProbe) trackPeer(ctx context.Context, probe *sink.Probe, want *Want) (*sink.Sighting, error) {
+	stats.Record(ctx, metrics.TrackCount.M(atomic.AddInt64(&p.trackCount, 1)))
+
+	identified := p.identifier.Wait(ctx, want.PeerID, IdentifyTimeout)
+	rtt := pingPeer(ctx, p.host, want.PeerID)
+
+	sighting := newSighting(ctx, p.host, p.mmc, probe, want.PeerID)
+	sighting.Identified = identified
+	sighting.PingRTT = rtt
+	sighting.SeenAt = want.SeenAt
+	sighting.Want = &sink.Want{
+		Type:         wantTypes[want.WantType],
+		Priority:     want.Priority,
+		SendDontHave: want.SendDontHave,
 	}
 
-	maddrSet := map[string]ma.Multiaddr{}
-	for _, maddr := range ps.Addrs(peerID) {
-		maddrSet[maddr.String()] = maddr
-	}
-	for _, conn := range p.host.Network().ConnsToPeer(peerID) {
-		maddr := conn.RemoteMultiaddr()
-		maddrSet[maddr.String()] = maddr
+	if err := p.sink.TrackSighting(ctx, sighting); err != nil {
+		return nil, errors.Wrap(err, "track sighting")
 	}
 
-	maddrStrs := []string{}
-	ipAddressesSet := goset.NewSet[string]()
-	countriesSet := goset.NewSet[string]()
-	continentsSet := goset.NewSet[string]()
-	asnsSet := goset.NewSet[int64]()
-
-	for maddrStr, maddr := range maddrSet {
-		if utils.IsRelayedMaddr(maddr) || !manet.IsPublicAddr(maddr) {
-			continue
-		}
-
-		maddrStrs = append(maddrStrs, maddrStr)
-		maddrInfos, err := p.mmc.MaddrInfo(ctx, maddr)
-		if err != nil {
-			continue
-		}
-
-		for ipAddress, maddrInfo := range maddrInfos {
-			ipAddressesSet.Add(ipAddress)
-			countriesSet.Add(maddrInfo.Country)
-			continentsSet.Add(maddrInfo.Continent)
-			asnsSet.Add(int64(maddrInfo.ASN))
-		}
-	}
-
-	ipAddressesSet.Discard("")
-	countriesSet.Discard("")
-	continentsSet.Discard("")
-	asnsSet.Discard(0)
-
-	ipAddresses := ipAddressesSet.Items()
-	countries := countriesSet.Items()
-	continents := continentsSet.Items()
-	asns := asnsSet.Items()
-
-	sort.Strings(ipAddresses)
-	sort.Strings(countries)
-	sort.Strings(continents)
-	sort.Slice(asns, func(i, j int) bool { return asns[i] < asns[j] })
-
-	return insertModel(ctx, p.config.Database.DryRun, p.dbc, p.logEntry(), protocols, agentVersion, peerID,
-		ipAddresses, maddrStrs, countries, continents, asns, p.target.Type(), p.target.Name())
+	return sighting, nil
 }
 
 func (p *PinProbe) wait() {
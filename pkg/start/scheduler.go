@@ -2,7 +2,18 @@ package start
 
 import (
 	"context"
-
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 	"github.com/ipfs/go-bitswap"
 	bsnet "github.com/ipfs/go-bitswap/network"
 	"github.com/ipfs/go-datastore"
@@ -11,16 +22,27 @@ import (
 	"github.com/libp2p/go-libp2p"
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/core/routing"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/libp2p/go-libp2p/p2p/host/resource-manager/obs"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/dennis-tra/antares/pkg/config"
-	"github.com/dennis-tra/antares/pkg/db"
 	"github.com/dennis-tra/antares/pkg/maxmind"
+	"github.com/dennis-tra/antares/pkg/sink"
+	"github.com/dennis-tra/antares/pkg/utils"
 )
 
+// ConfigReloadDelay is the time the configuration file must remain unchanged before the targets are reloaded.
+// Editors often write files in multiple steps, which would otherwise trigger multiple reloads.
+var ConfigReloadDelay = time.Second
+
 // The Scheduler is responsible for the initialization of Targets and Probes. Targets are entities like gateways
 // or pinning services. Probes can be configured with a specific Target and carry out the publication of content and
 // later the request through the Target. After all targets are initialized from the configuration, they get assigned
@@ -31,8 +53,8 @@ type Scheduler struct {
 	// traffic is then used to detect who requested those CIDs.
 	host host.Host
 
-	// A handle on the database to issue queries.
-	dbc *db.Client
+	// The sink that receives the results of all probes.
+	sink sink.Sink
 
 	// A handle on the Maxmind GeoIP2 database to resolve IP addresses to country and continent information.
 	mmc *maxmind.Client
@@ -47,34 +69,125 @@ type Scheduler struct {
 	// a Bitswap message leaves the Antares libp2p host or is received by it.
 	tracer *Tracer
 
+	// The honeypot is handed into the DHT and gets to see all incoming DHT requests to detect peers that look up the
+	// CIDs that we provide.
+	honeypot *Honeypot
+
+	// The identifier keeps track of the identify exchanges with connected peers, so that probes can wait for the
+	// agent versions and protocols of the peers they record.
+	identifier *Identifier
+
 	// A reference to the underlying blockstore that Bitswap uses to deliver the blocks that were previously advertised
 	// via their CID to the DHT
 	bstore blockstore.Blockstore
 
+	// The peers that the host connects to before the probes are started.
+	bootstrapPeers []peer.AddrInfo
+
 	// A list of Targets to probe.
-	targets []Target
+	targets []targetEntry
+
+	// The probes that are currently running indexed by the key of their target.
+	probes map[string]*runningProbe
+
+	// Limits the number of probes that are in flight at the same time across all targets.
+	slots probeSlots
+
+	// Keeps track of all probe go-routines including the ones that were stopped because of a reload.
+	probesWg sync.WaitGroup
 }
 
-// NewScheduler initializes a new libp2p host with the given configuration handles to a persistent storage and Maxmind
+// A targetEntry is a Target together with the configuration that it was constructed from. When the
+// configuration is reloaded, the configuration is compared to detect whether the Target has changed.
+type targetEntry struct {
+	target Target
+	conf   any
+}
+
+// runningProbe is a probe that was started by the scheduler together with the means to stop it.
+type runningProbe struct {
+	Probe
+	conf   any
+	cancel context.CancelFunc
+}
+
+// targetKey identifies a target across configuration reloads.
+func targetKey(t Target) string {
+	return t.Type() + "/" + t.Name()
+}
+
+// NewScheduler initializes a new libp2p host with the given configuration handles to a result sink and Maxmind
 // GeoIP2 database.
-func NewScheduler(ctx context.Context, conf *config.Config, dbc *db.Client, mmc *maxmind.Client) (*Scheduler, error) {
-	// TODO: Still haven't fully grasped how to properly configure the resource manager...
-	mgr, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(rcmgr.InfiniteLimits))
+func NewScheduler(ctx context.Context, conf *config.Config, snk sink.Sink, mmc *maxmind.Client) (*Scheduler, error) {
+	reporter, err := obs.NewStatsTraceReporter()
+	if err != nil {
+		return nil, errors.Wrap(err, "new resource manager stats reporter")
+	}
+
+	limits := resourceLimits(conf.ResourceManager)
+	mgr, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits), rcmgr.WithTraceReporter(reporter))
 	if err != nil {
 		return nil, errors.Wrap(err, "new resource manager")
 	}
+	log.WithFields(log.Fields{
+		"conns":  limits.System.Conns,
+		"fds":    limits.System.FD,
+		"memory": limits.System.Memory,
+	}).Debugln("Initialized resource manager")
+
+	cm, err := connmgr.NewConnManager(
+		conf.ConnectionManager.LowWater,
+		conf.ConnectionManager.HighWater,
+		connmgr.WithGracePeriod(conf.ConnectionManager.GracePeriod),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "new connection manager")
+	}
+
+	listenMaddrs, err := conf.ListenMaddrs()
+	if err != nil {
+		return nil, errors.Wrap(err, "listen addresses")
+	}
+
+	bootstrapPeers, err := conf.Network.BootstrapAddrInfos()
+	if err != nil {
+		return nil, errors.Wrap(err, "bootstrap peers")
+	}
+
+	psk, err := conf.Network.SwarmKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "swarm key")
+	}
+
+	// QUIC based transports don't support private networks
+	transports := libp2p.ChainOptions(libp2p.DefaultTransports, libp2p.Transport(webtransport.New))
+	if psk != nil {
+		log.Infoln("Joining private network")
+		transports = libp2p.ChainOptions(libp2p.DefaultPrivateTransports, libp2p.PrivateNetwork(psk))
+		listenMaddrs = privateListenMaddrs(listenMaddrs)
+	}
+
+	dhtOpts := []kaddht.Option{kaddht.BootstrapPeers(bootstrapPeers...)}
+	if conf.Network.ProtocolPrefix != "" {
+		dhtOpts = append(dhtOpts, kaddht.ProtocolPrefix(protocol.ID(conf.Network.ProtocolPrefix)))
+	}
+
+	// Create a new honeypot that inspects DHT requests
+	hp := NewHoneypot()
 
 	// Initialize the libp2p host
 	var dht *kaddht.IpfsDHT
 	h, err := libp2p.New(
 		libp2p.Identity(conf.PrivKey),
-		libp2p.ListenAddrs(conf.ListenAddrTCP, conf.ListenAddrQUIC),
+		libp2p.ListenAddrs(listenMaddrs...),
+		transports,
 		libp2p.UserAgent("antares/"+conf.Version),
 		libp2p.Routing(func(h host.Host) (routing.PeerRouting, error) {
-			dht, err = kaddht.New(ctx, h)
+			dht, err = kaddht.New(ctx, hp.Host(h), dhtOpts...)
 			return dht, err
 		}),
 		libp2p.ResourceManager(mgr),
+		libp2p.ConnectionManager(cm),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "new libp2p host")
@@ -83,6 +196,12 @@ func NewScheduler(ctx context.Context, conf *config.Config, dbc *db.Client, mmc
 	// Create a new tracer
 	t := NewTracer()
 
+	// Follow the identify exchanges, so that probes can wait for them before recording a peer
+	id, err := NewIdentifier(ctx, h)
+	if err != nil {
+		return nil, errors.Wrap(err, "new identifier")
+	}
+
 	// Configure the Bitswap submodule
 	network := bsnet.NewFromIpfsHost(h, dht)
 	ds := dssync.MutexWrap(datastore.NewMapDatastore())
@@ -99,14 +218,19 @@ func NewScheduler(ctx context.Context, conf *config.Config, dbc *db.Client, mmc
 	}
 
 	return &Scheduler{
-		host:    h,
-		dbc:     dbc,
-		mmc:     mmc,
-		config:  conf,
-		dht:     dht,
-		tracer:  t,
-		bstore:  bstore,
-		targets: targets,
+		host:           h,
+		sink:           snk,
+		mmc:            mmc,
+		config:         conf,
+		dht:            dht,
+		tracer:         t,
+		honeypot:       hp,
+		identifier:     id,
+		bootstrapPeers: bootstrapPeers,
+		bstore:         bstore,
+		targets:        targets,
+		probes:         map[string]*runningProbe{},
+		slots:          newProbeSlots(conf.MaxConcurrentProbes),
 	}, nil
 }
 
@@ -114,13 +238,31 @@ func NewScheduler(ctx context.Context, conf *config.Config, dbc *db.Client, mmc
 // A Target is just the entity that we are probing to detect their PeerIDs and can be gateways or pinning services.
 // It always adds a dummy target. For each entry in the `Gateways` and `PinningServices` list it also
 // creates a corresponding target.
-func initTargets(h host.Host, conf *config.Config) ([]Target, error) {
+func initTargets(h host.Host, conf *config.Config) ([]targetEntry, error) {
 	// Always add the dummy target to detect peers that are proactively
-	targets := []Target{NewDummyTarget()}
+	targets := []targetEntry{{target: NewDummyTarget()}}
+
+	// All targets share the HTTP client unless they override its configuration
+	httpDefaults := conf.HTTP
+	if httpDefaults.UserAgent == "" {
+		httpDefaults.UserAgent = "antares/" + conf.Version
+	}
+	clients := newHTTPClients(httpDefaults)
 
 	// Add all configured gateways
 	for _, gw := range conf.Gateways {
-		targets = append(targets, NewGatewayTarget(gw.Name, gw.URL))
+		client, err := clients.get(gw.HTTP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "http client for gateway target: %s", gw.Name)
+		}
+
+		gwt, err := NewGatewayTarget(client, gw, h.ID())
+		if err != nil {
+			return nil, errors.Wrapf(err, "constructing gateway target: %s", gw.Name)
+		}
+
+		gw.HTTP = gw.HTTP.WithDefaults(httpDefaults)
+		targets = append(targets, targetEntry{target: gwt, conf: gw})
 	}
 
 	// Add all configured pinning services
@@ -131,12 +273,24 @@ func initTargets(h host.Host, conf *config.Config) ([]Target, error) {
 			continue
 		}
 
-		pst, err := tc(h, ps.Authorization)
+		client, err := clients.get(ps.HTTP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "http client for pinning service target: %s", ps.Target)
+		}
+
+		// Only the copy of the configuration that's handed to the target contains the secret
+		ps.Authorization, err = config.ResolveSecret(ps.Authorization)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve authorization of pinning service target: %s", ps.Target)
+		}
+
+		pst, err := tc(h, client, ps)
 		if err != nil {
 			return nil, errors.Wrapf(err, "constructing pinning service target: %s", ps.Target)
 		}
 
-		targets = append(targets, pst)
+		ps.HTTP = ps.HTTP.WithDefaults(httpDefaults)
+		targets = append(targets, targetEntry{target: pst, conf: ps})
 	}
 
 	for _, us := range conf.UploadServices {
@@ -146,78 +300,301 @@ func initTargets(h host.Host, conf *config.Config) ([]Target, error) {
 			continue
 		}
 
-		ust, err := tc(h, us.Authorization)
+		client, err := clients.get(us.HTTP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "http client for upload service target: %s", us.Target)
+		}
+
+		us.Authorization, err = config.ResolveSecret(us.Authorization)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve authorization of upload service target: %s", us.Target)
+		}
+
+		ust, err := tc(h, client, us)
 
 		if err != nil {
 			return nil, errors.Wrapf(err, "constructing pinning service target: %s", us.Target)
 		}
 
-		targets = append(targets, ust)
+		us.HTTP = us.HTTP.WithDefaults(httpDefaults)
+		targets = append(targets, targetEntry{target: ust, conf: us})
 	}
 
 	return targets, nil
 }
 
 // StartProbes connects to the IPFS bootstrap peers and starts each target probe in their own go-routine.
+// While running, it reloads the targets whenever the configuration file changes or Antares receives a SIGHUP.
 func (s *Scheduler) StartProbes(ctx context.Context) error {
-	// Connect to IPFS bootstrap peers
-	for _, bp := range kaddht.GetDefaultBootstrapPeerAddrInfos() {
-		log.WithField("peerID", bp.ID).Infoln("Connecting to bootstrap peer")
-		if err := s.host.Connect(ctx, bp); err != nil {
-			return errors.Wrap(err, "connect to bootstrap peer")
-		}
+	if err := s.connectBootstrapPeers(ctx); err != nil {
+		return err
 	}
 
 	// Start all probes
-	var probes []Probe
-	for _, target := range s.targets {
-		log.Infof("Starting %s probe %s...", target.Type(), target.Name())
-		var p Probe
-		switch target.(type) {
-		case PinTarget:
-			p = s.newProbe(target.(PinTarget))
-		case UploadTarget:
-			p = s.newUploadProbe(target.(UploadTarget))
+	s.applyTargets(ctx, s.targets)
+	log.WithField("count", len(s.probes)).Infoln("Initialized all target probes!")
+
+	// Block until the user wants to stop and reload the targets in the meantime
+	reloads := s.watchConfig(ctx)
+	for ctx.Err() == nil {
+		select {
+		case <-reloads:
+			if err := s.reload(ctx); err != nil {
+				log.WithError(err).Warnln("Error reloading targets, keeping the current ones")
+			}
+		case <-ctx.Done():
 		}
-		probes = append(probes, p)
-		go p.run(ctx)
 	}
 
-	// Block until the user wants to stop
-	log.WithField("count", len(s.targets)).Infoln("Initialized all target probes!")
-	<-ctx.Done()
-
 	// The user wanted to stop the program, wait until all probes have gracefully stopped
-	for _, p := range probes {
+	for _, p := range s.probes {
 		p.logEntry().Infoln("Waiting for probe to stop")
 		p.wait()
 	}
+	s.probesWg.Wait()
 
 	return nil
 }
 
+// connectBootstrapPeers connects to all bootstrap peers in parallel. It fails if it couldn't connect
+// to at least the configured minimum number of them.
+func (s *Scheduler) connectBootstrapPeers(ctx context.Context) error {
+	var connected int32
+
+	var wg sync.WaitGroup
+	for _, bp := range s.bootstrapPeers {
+		wg.Add(1)
+		go func(bp peer.AddrInfo) {
+			defer wg.Done()
+
+			logEntry := log.WithField("peerID", bp.ID)
+			logEntry.Infoln("Connecting to bootstrap peer")
+			if err := s.host.Connect(ctx, bp); err != nil {
+				logEntry.WithError(err).Warnln("Error connecting to bootstrap peer")
+				return
+			}
+			atomic.AddInt32(&connected, 1)
+		}(bp)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if int(connected) < s.config.Network.MinBootstrapPeers {
+		return fmt.Errorf("connected to %d of %d bootstrap peers but at least %d are required", connected, len(s.bootstrapPeers), s.config.Network.MinBootstrapPeers)
+	}
+	log.WithField("count", connected).Infoln("Connected to bootstrap peers")
+
+	return nil
+}
+
+// privateListenMaddrs removes the multi addresses of all transports that don't support private networks.
+func privateListenMaddrs(maddrs []ma.Multiaddr) []ma.Multiaddr {
+	var private []ma.Multiaddr
+	for _, maddr := range maddrs {
+		switch utils.MaddrTransport(maddr) {
+		case utils.TransportTCP, utils.TransportWebSocket:
+			private = append(private, maddr)
+		default:
+			log.WithField("maddr", maddr).Warnln("Not listening on address of a transport that doesn't support private networks")
+		}
+	}
+	return private
+}
+
+// reload reads the configuration file again and applies the changes of its targets to the running probes.
+// The libp2p host, DHT and Bitswap instance are kept. So are all other configuration values.
+func (s *Scheduler) reload(ctx context.Context) error {
+	log.WithField("path", s.config.Path).Infoln("Reloading targets from configuration...")
+	conf, err := config.Reload(s.config.Path)
+	if err != nil {
+		return errors.Wrap(err, "reload configuration")
+	}
+	conf.Version = s.config.Version
+
+	targets, err := initTargets(s.host, conf)
+	if err != nil {
+		return errors.Wrap(err, "init targets")
+	}
+
+	s.applyTargets(ctx, targets)
+	log.WithField("count", len(s.probes)).Infoln("Reloaded targets!")
+
+	return nil
+}
+
+// applyTargets starts, stops or restarts probes, so that the given targets are probed. Probes of targets
+// whose configuration hasn't changed keep running undisturbed.
+func (s *Scheduler) applyTargets(ctx context.Context, targets []targetEntry) {
+	running := make(map[string]any, len(s.probes))
+	for key, p := range s.probes {
+		running[key] = p.conf
+	}
+
+	add, remove := diffTargets(running, targets)
+	for _, key := range remove {
+		p := s.probes[key]
+		p.logEntry().Infoln("Stopping probe...")
+		p.cancel()
+		delete(s.probes, key)
+	}
+
+	for _, t := range add {
+		log.Infof("Starting %s probe %s...", t.target.Type(), t.target.Name())
+		s.startProbe(ctx, t)
+	}
+
+	s.targets = targets
+}
+
+// diffTargets compares the configurations of the running probes, indexed by the keys of their targets, with
+// the given targets. It returns the targets whose probes must be started and the keys of the probes that
+// must be stopped. Targets whose configuration has changed are part of both. If multiple targets have the
+// same key, only the first one is considered.
+func diffTargets(running map[string]any, targets []targetEntry) ([]targetEntry, []string) {
+	var add []targetEntry
+	var remove []string
+
+	seen := map[string]struct{}{}
+	for _, t := range targets {
+		key := targetKey(t.target)
+		if _, found := seen[key]; found {
+			log.WithField("key", key).Warnln("Ignoring duplicate target")
+			continue
+		}
+		seen[key] = struct{}{}
+
+		conf, found := running[key]
+		if found && reflect.DeepEqual(conf, t.conf) {
+			continue
+		} else if found {
+			remove = append(remove, key)
+		}
+		add = append(add, t)
+	}
+
+	for key := range running {
+		if _, found := seen[key]; !found {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// startProbe starts a new probe of the given target in its own go-routine.
+func (s *Scheduler) startProbe(ctx context.Context, t targetEntry) {
+	var p Probe
+	switch target := t.target.(type) {
+	case PinTarget:
+		p = s.newProbe(target)
+	case UploadTarget:
+		p = s.newUploadProbe(target)
+	default:
+		log.Warnf("no probe for %s target %s\n", t.target.Type(), t.target.Name())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.probes[targetKey(t.target)] = &runningProbe{Probe: p, conf: t.conf, cancel: cancel}
+
+	s.probesWg.Add(1)
+	go func() {
+		defer s.probesWg.Done()
+		p.run(ctx)
+	}()
+}
+
+// watchConfig returns a channel that receives a value whenever the configuration file was changed or Antares
+// received a SIGHUP. Changes to the file are only reported after it hasn't changed for ConfigReloadDelay.
+func (s *Scheduler) watchConfig(ctx context.Context) <-chan struct{} {
+	reloads := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case reloads <- struct{}{}:
+		default:
+		}
+	}
+
+	hups := make(chan os.Signal, 1)
+	signal.Notify(hups, syscall.SIGHUP)
+
+	// Watch the directory because editors and configuration management tools often replace the file
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(filepath.Dir(s.config.Path))
+	}
+	if err != nil {
+		log.WithError(err).Warnln("Error watching configuration file, only reloading targets on SIGHUP")
+	} else {
+		events = watcher.Events
+		errs = watcher.Errors
+	}
+
+	go func() {
+		defer signal.Stop(hups)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+
+		var changed <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hups:
+				log.Infoln("Received SIGHUP")
+				notify()
+			case event := <-events:
+				if filepath.Clean(event.Name) == filepath.Clean(s.config.Path) {
+					changed = time.After(ConfigReloadDelay)
+				}
+			case err := <-errs:
+				log.WithError(err).Warnln("Error watching configuration file")
+			case <-changed:
+				log.Infoln("Configuration file changed")
+				changed = nil
+				notify()
+			}
+		}
+	}()
+
+	return reloads
+}
+
 func (s *Scheduler) newProbe(target PinTarget) *PinProbe {
 	return &PinProbe{
-		host:   s.host,
-		dbc:    s.dbc,
-		mmc:    s.mmc,
-		config: s.config,
-		dht:    s.dht,
-		bstore: s.bstore,
-		tracer: s.tracer,
-		target: target,
-		done:   make(chan struct{}),
+		host:       s.host,
+		sink:       s.sink,
+		mmc:        s.mmc,
+		config:     s.config,
+		dht:        s.dht,
+		bstore:     s.bstore,
+		tracer:     s.tracer,
+		honeypot:   s.honeypot,
+		identifier: s.identifier,
+		target:     target,
+		slots:      s.slots,
+		done:       make(chan struct{}),
 	}
 }
 
 func (s *Scheduler) newUploadProbe(target UploadTarget) *UploadProbe {
 	return &UploadProbe{
-		host:   s.host,
-		dbc:    s.dbc,
-		mmc:    s.mmc,
-		config: s.config,
-		dht:    s.dht,
-		target: target,
-		done:   make(chan struct{}),
+		host:       s.host,
+		sink:       s.sink,
+		mmc:        s.mmc,
+		config:     s.config,
+		dht:        s.dht,
+		honeypot:   s.honeypot,
+		identifier: s.identifier,
+		target:     target,
+		slots:      s.slots,
+		done:       make(chan struct{}),
 	}
 }
@@ -0,0 +1,147 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/pnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+func TestDiffTargets(t *testing.T) {
+	gw := func(name string, url string) targetEntry {
+		conf := config.Gateway{Name: name, URL: url}
+		target, err := NewGatewayTarget(nil, conf, "")
+		require.NoError(t, err)
+		return targetEntry{target: target, conf: conf}
+	}
+
+	dummy := targetEntry{target: NewDummyTarget()}
+	unchanged := gw("ipfs.io", "https://ipfs.io/ipfs/{cid}")
+	changed := gw("dweb.link", "https://{cid}.ipfs.dweb.link")
+	added := gw("w3s.link", "https://w3s.link/ipfs/{cid}")
+
+	running := map[string]any{
+		"honeypot/dummy":    nil,
+		"gateway/ipfs.io":   unchanged.conf,
+		"gateway/dweb.link": config.Gateway{Name: "dweb.link", URL: "https://dweb.link/ipfs/{cid}"},
+		"gateway/removed":   config.Gateway{Name: "removed", URL: "https://removed/ipfs/{cid}"},
+	}
+
+	add, remove := diffTargets(running, []targetEntry{dummy, unchanged, changed, added, gw("w3s.link", "https://duplicate/ipfs/{cid}")})
+	assert.Equal(t, []targetEntry{changed, added}, add)
+	assert.Equal(t, []string{"gateway/dweb.link", "gateway/removed"}, remove)
+
+	add, remove = diffTargets(map[string]any{}, []targetEntry{dummy, unchanged})
+	assert.Equal(t, []targetEntry{dummy, unchanged}, add)
+	assert.Empty(t, remove)
+}
+
+func TestScheduler_watchConfig(t *testing.T) {
+	defer func(delay time.Duration) { ConfigReloadDelay = delay }(ConfigReloadDelay)
+	ConfigReloadDelay = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &Scheduler{config: &config.Config{Path: path}}
+	reloads := s.watchConfig(ctx)
+
+	// Changes to other files in the same directory must be ignored
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "other.json"), []byte("{}"), 0o600))
+	select {
+	case <-reloads:
+		t.Fatal("unexpected reload")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Multiple writes in quick succession only trigger a single reload
+	for i := 0; i < 3; i++ {
+		require.NoError(t, os.WriteFile(path, []byte(`{"Port": 2002}`), 0o600))
+	}
+	select {
+	case <-reloads:
+	case <-time.After(time.Second):
+		t.Fatal("no reload after the configuration file changed")
+	}
+	select {
+	case <-reloads:
+		t.Fatal("unexpected reload")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case <-reloads:
+	case <-time.After(time.Second):
+		t.Fatal("no reload after SIGHUP")
+	}
+}
+
+func writeSwarmKey(t *testing.T, key string) string {
+	path := filepath.Join(t.TempDir(), "swarm.key")
+	require.NoError(t, os.WriteFile(path, []byte("/key/swarm/psk/1.0.0/\n/base16/\n"+key+"\n"), 0o600))
+	return path
+}
+
+func TestScheduler_connectBootstrapPeers(t *testing.T) {
+	swarmKey := writeSwarmKey(t, strings.Repeat("ab", 32))
+
+	conf := config.DefaultConfig
+	conf.Network.SwarmKeyPath = swarmKey
+	psk, err := conf.Network.SwarmKey()
+	require.NoError(t, err)
+
+	// A bootstrap peer of the private network and one of another network
+	var bootstrapPeers []string
+	for _, key := range []pnet.PSK{psk, pnet.PSK(bytes.Repeat([]byte{0xcd}, 32))} {
+		h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"), libp2p.DefaultPrivateTransports, libp2p.PrivateNetwork(key))
+		require.NoError(t, err)
+		defer h.Close()
+
+		bootstrapPeers = append(bootstrapPeers, fmt.Sprintf("%s/p2p/%s", h.Addrs()[0], h.ID()))
+	}
+
+	conf.Host = "127.0.0.1"
+	conf.Port = 0
+	conf.Network.BootstrapPeers = bootstrapPeers
+	conf.Network.ProtocolPrefix = "/antares-test"
+	conf.PrivKey, _, err = crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewScheduler(ctx, &conf, nil, nil)
+	require.NoError(t, err)
+	defer s.host.Close()
+
+	// QUIC and WebTransport don't support private networks
+	for _, maddr := range s.host.Network().ListenAddresses() {
+		assert.NotContains(t, []string{utils.TransportQUIC, utils.TransportQUICV1, utils.TransportWebTransport}, utils.MaddrTransport(maddr))
+	}
+
+	conf.Network.MinBootstrapPeers = 1
+	assert.NoError(t, s.connectBootstrapPeers(ctx))
+	assert.Len(t, s.host.Network().Peers(), 1)
+
+	conf.Network.MinBootstrapPeers = 2
+	assert.Error(t, s.connectBootstrapPeers(ctx))
+}
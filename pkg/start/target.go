@@ -2,22 +2,31 @@ package start
 
 import (
 	"context"
-	blocks "github.com/ipfs/go-block-format"
+	"net/http"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/host"
+
+	"github.com/dennis-tra/antares/pkg/config"
 )
 
-type PinningServiceTargetConstructor = func(host host.Host, auth string) (PinTarget, error)
+// PinningServiceTargetConstructor constructs a pinning service target. Targets must send all their
+// requests with the given HTTP client.
+type PinningServiceTargetConstructor = func(host host.Host, client *http.Client, conf config.PinningService) (PinTarget, error)
 
 var PinningServiceTargetConstructors = map[string]PinningServiceTargetConstructor{
 	InfuraTargetName: NewInfura,
+	KuboTargetName:   NewKubo,
 	PinataTargetName: NewPinata,
+	PSATargetName:    NewPSA,
 }
 
-type UploadServiceTargetConstructor = func(host host.Host, auth string) (UploadTarget, error)
+// UploadServiceTargetConstructor constructs an upload service target. Targets must send all their
+// requests with the given HTTP client.
+type UploadServiceTargetConstructor = func(host host.Host, client *http.Client, conf config.UploadService) (UploadTarget, error)
 
 var UploadServiceTargetConstructors = map[string]UploadServiceTargetConstructor{
 	Web3TargetName: NewWeb3,
@@ -27,6 +36,7 @@ type Target interface {
 	Backoff(ctx context.Context) backoff.BackOff
 	Timeout() time.Duration
 	Rate() time.Duration
+	Concurrency() int
 	Name() string
 	Type() string
 }
@@ -35,6 +45,13 @@ type PinTarget interface {
 	Operation(ctx context.Context, c cid.Cid) error
 }
 
+// A VerifyingTarget retrieves the probed content and verifies that it belongs to the probe. Its Operation
+// fails with ErrCorruptedContent or ErrStaleContent if that's not the case.
+type VerifyingTarget interface {
+	PinTarget
+	VerifiesContent() bool
+}
+
 type CleanupTarget interface {
 	Target
 	CleanUp(ctx context.Context, c cid.Cid) error
@@ -44,3 +61,48 @@ type UploadTarget interface {
 	Target
 	UploadContent(ctx context.Context, block *blocks.BasicBlock) error
 }
+
+// probeSettings implements the scheduling related methods of the Target interface based on
+// the probe configuration of a target. Targets embed it to let users configure them.
+type probeSettings struct {
+	conf config.Probe
+}
+
+// newProbeSettings initializes the probe settings from the given configuration and
+// falls back to the given defaults for all values that are not configured.
+func newProbeSettings(conf config.Probe, defaults config.Probe) probeSettings {
+	return probeSettings{conf: conf.WithDefaults(defaults)}
+}
+
+func (s probeSettings) Backoff(ctx context.Context) backoff.BackOff {
+	bo := &backoff.ExponentialBackOff{
+		InitialInterval: s.conf.Backoff.InitialInterval,
+		Multiplier:      s.conf.Backoff.Multiplier,
+		MaxInterval:     s.conf.Backoff.MaxInterval,
+		Stop:            backoff.Stop,
+		Clock:           backoff.SystemClock,
+	}
+	if s.conf.Backoff.RandomizationFactor != nil {
+		bo.RandomizationFactor = *s.conf.Backoff.RandomizationFactor
+	}
+	if s.conf.Backoff.MaxElapsedTime != nil {
+		bo.MaxElapsedTime = *s.conf.Backoff.MaxElapsedTime
+	}
+	return backoff.WithContext(bo, ctx)
+}
+
+func (s probeSettings) Rate() time.Duration {
+	return s.conf.Rate
+}
+
+func (s probeSettings) Timeout() time.Duration {
+	return s.conf.Timeout
+}
+
+// Concurrency returns how many probes of the target may be in flight at the same time. It's at least one.
+func (s probeSettings) Concurrency() int {
+	if s.conf.Concurrency < 1 {
+		return 1
+	}
+	return s.conf.Concurrency
+}
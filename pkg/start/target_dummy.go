@@ -7,13 +7,33 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/ipfs/go-cid"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
 )
 
 // DummyTarget is here to detect peers that sniff DHT traffic. No one should ever request data provided by this target.
-type DummyTarget struct{}
+type DummyTarget struct {
+	probeSettings
+}
+
+// DummyDefaults are the probe settings of the dummy target.
+var DummyDefaults = config.Probe{
+	Rate:    time.Minute,
+	Timeout: 5 * time.Minute,
+	Backoff: config.Backoff{
+		InitialInterval:     backoff.DefaultInitialInterval,
+		RandomizationFactor: utils.Ptr(backoff.DefaultRandomizationFactor),
+		Multiplier:          backoff.DefaultMultiplier,
+		MaxInterval:         backoff.DefaultMaxInterval,
+		MaxElapsedTime:      utils.Ptr(backoff.DefaultMaxElapsedTime),
+	},
+}
 
 func NewDummyTarget() *DummyTarget {
-	return &DummyTarget{}
+	return &DummyTarget{
+		probeSettings: newProbeSettings(config.Probe{}, DummyDefaults),
+	}
 }
 
 var _ Target = (*DummyTarget)(nil)
@@ -23,18 +43,6 @@ func (dt *DummyTarget) Operation(ctx context.Context, c cid.Cid) error {
 	return nil
 }
 
-func (dt *DummyTarget) Backoff(ctx context.Context) backoff.BackOff {
-	return backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
-}
-
-func (dt *DummyTarget) Rate() time.Duration {
-	return time.Minute
-}
-
-func (dt *DummyTarget) Timeout() time.Duration {
-	return 5 * time.Minute
-}
-
 func (dt *DummyTarget) Name() string {
 	return "dummy"
 }
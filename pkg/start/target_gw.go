@@ -1,91 +1,346 @@
 package start
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/sink"
 	"github.com/dennis-tra/antares/pkg/utils"
 )
 
 const GatewayURLReplaceStr = "{cid}"
 
+// Gateway modes determine how the content is requested from a gateway.
+const (
+	// GatewayModePath requests the content via its path and expects the deserialized payload.
+	GatewayModePath = "path"
+
+	// GatewayModeRaw requests the raw block with the ?format=raw query parameter.
+	GatewayModeRaw = "raw"
+
+	// GatewayModeCAR requests a CAR file with the ?format=car query parameter.
+	GatewayModeCAR = "car"
+
+	// GatewayModeAcceptRaw requests the raw block with the application/vnd.ipld.raw Accept header.
+	GatewayModeAcceptRaw = "accept-raw"
+
+	// GatewayModeAcceptCAR requests a CAR file with the application/vnd.ipld.car Accept header.
+	GatewayModeAcceptCAR = "accept-car"
+)
+
+// GatewayResponseHeaders are the headers of gateway responses that are recorded with each probe. They reveal
+// the CDN point of presence and the caching layers of the gateway.
+var GatewayResponseHeaders = []string{
+	"Server",
+	"X-Ipfs-Path",
+	"X-Ipfs-Roots",
+	"X-Cache",
+	"CF-Ray",
+	"Via",
+	"X-Served-By",
+	"Age",
+}
+
+// tlsVersions maps TLS versions to their names.
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// Content types of trustless gateway responses.
+const (
+	ContentTypeRaw = "application/vnd.ipld.raw"
+	ContentTypeCAR = "application/vnd.ipld.car"
+)
+
+// GatewayDefaults are the probe settings of a gateway if not configured otherwise.
+var GatewayDefaults = config.Probe{
+	Rate:    2 * time.Minute,
+	Timeout: 11 * time.Minute,
+	Backoff: config.Backoff{
+		InitialInterval:     30 * time.Second,
+		RandomizationFactor: utils.Ptr(0.5),
+		Multiplier:          1.2,
+		MaxInterval:         2 * time.Minute,
+		MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+	},
+}
+
 type Gateway struct {
+	probeSettings
 	name   string
 	urlFmt string
+	mode   string
+	client *http.Client
+
+	// The peer that signed the probed content. Retrieved content must carry its signature.
+	signer peer.ID
+
+	// Whether the CID is part of the host name (e.g., https://{cid}.ipfs.dweb.link). Subdomain gateways
+	// require case-insensitive CIDs, so the CID is encoded as CIDv1 in base32.
+	subdomain bool
 }
 
-func NewGatewayTarget(name string, urlFmt string) *Gateway {
+func NewGatewayTarget(client *http.Client, conf config.Gateway, signer peer.ID) (*Gateway, error) {
+	mode := strings.ToLower(conf.Mode)
+	switch mode {
+	case "":
+		mode = GatewayModePath
+	case GatewayModePath, GatewayModeRaw, GatewayModeCAR, GatewayModeAcceptRaw, GatewayModeAcceptCAR:
+	default:
+		return nil, fmt.Errorf("unknown gateway mode %s", conf.Mode)
+	}
+
+	if _, err := url.Parse(strings.ReplaceAll(conf.URL, GatewayURLReplaceStr, "cid")); err != nil {
+		return nil, errors.Wrap(err, "parse gateway url")
+	}
+
 	return &Gateway{
-		name:   name,
-		urlFmt: urlFmt,
+		probeSettings: newProbeSettings(conf.Probe, GatewayDefaults),
+		name:          conf.Name,
+		urlFmt:        conf.URL,
+		mode:          mode,
+		client:        client,
+		signer:        signer,
+		subdomain:     strings.Contains(urlHost(conf.URL), GatewayURLReplaceStr),
+	}, nil
+}
+
+// urlHost returns the host part of the given URL format string. It doesn't use url.Parse
+// because the CID placeholder isn't a valid host name.
+func urlHost(urlFmt string) string {
+	if i := strings.Index(urlFmt, "://"); i >= 0 {
+		urlFmt = urlFmt[i+3:]
+	}
+	if i := strings.IndexAny(urlFmt, "/?#"); i >= 0 {
+		urlFmt = urlFmt[:i]
 	}
+	return urlFmt
 }
 
-var _ Target = (*Gateway)(nil)
+var _ VerifyingTarget = (*Gateway)(nil)
 
 func (g *Gateway) Operation(ctx context.Context, c cid.Cid) error {
 	logEntry := g.logEntry().WithField("cid", c)
-	u := strings.ReplaceAll(g.urlFmt, GatewayURLReplaceStr, c.String())
 
-	logEntry.WithField("url", u).Infoln("Requesting cid from Gateway")
+	u, err := g.url(c)
+	if err != nil {
+		return backoff.Permanent(errors.Wrap(err, "gateway url"))
+	}
+
+	logEntry.WithField("url", u).WithField("mode", g.mode).Infoln("Requesting cid from Gateway")
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return errors.Wrap(err, "new request")
 	}
-	resp, err := http.DefaultClient.Do(req)
+
+	switch g.mode {
+	case GatewayModeAcceptRaw:
+		req.Header.Set("Accept", ContentTypeRaw)
+	case GatewayModeAcceptCAR:
+		req.Header.Set("Accept", ContentTypeCAR)
+	}
+
+	resp, err := g.client.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "request do")
 	}
 	defer resp.Body.Close()
 
+	// Also read the body of unsuccessful responses to record its size.
+	body, err := io.ReadAll(resp.Body)
+	recordResponse(ctx, newHTTPResponse(resp, len(body)))
+	if err != nil {
+		return errors.Wrap(err, "read request body")
+	}
+
 	if !utils.IsSuccessStatusCode(resp) {
 		return fmt.Errorf("status code %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	p, err := g.parsePayload(c, body)
+	if err == nil {
+		logEntry.WithField("msg", p.Message).WithField("ts", p.Timestamp).Debugln("Fetched data")
+		err = p.Verify(g.signer, notBefore(ctx))
+	}
+
+	// The gateway served content that doesn't belong to the probe. Retrying won't help.
 	if err != nil {
-		return errors.Wrap(err, "read request body")
+		return backoff.Permanent(errors.Wrap(err, "verify response"))
 	}
 
-	var p Payload
-	if err = json.Unmarshal(body, &p); err != nil {
-		return errors.Wrap(err, "read request data")
+	return nil
+}
+
+// VerifiesContent returns true because gateways always verify the content they serve.
+func (g *Gateway) VerifiesContent() bool {
+	return true
+}
+
+// parsePayload extracts the payload from the given response body depending on the gateway mode. Raw blocks and
+// CAR files are checked to hash to the given CID.
+func (g *Gateway) parsePayload(c cid.Cid, body []byte) (*Payload, error) {
+	switch g.mode {
+	case GatewayModeRaw, GatewayModeAcceptRaw:
+		if err := verifyRawBlock(c, body); err != nil {
+			return nil, err
+		}
+		return ParsePayloadBlock(body)
+	case GatewayModeCAR, GatewayModeAcceptCAR:
+		data, err := verifyCAR(c, body)
+		if err != nil {
+			return nil, err
+		}
+		return ParsePayloadBlock(data)
+	default:
+		return ParsePayload(body)
 	}
+}
 
-	logEntry.WithField("msg", p.Message).WithField("ts", p.Timestamp).Debugln("Fetched data")
+// notBefore returns the time before which payloads of the probe of the given context are considered stale.
+func notBefore(ctx context.Context) time.Time {
+	start := probeStart(ctx)
+	if start.IsZero() {
+		return start
+	}
+	return start.Add(-PayloadClockSkew)
+}
 
-	return nil
+// newHTTPResponse gathers the details of the given response whose body had the given size.
+func newHTTPResponse(resp *http.Response, size int) *sink.HTTPResponse {
+	r := &sink.HTTPResponse{
+		StatusCode: resp.StatusCode,
+		URL:        resp.Request.URL.String(),
+		Bytes:      int64(size),
+	}
+
+	// Each request that followed a redirect references the response that caused it.
+	for req := resp.Request; req.Response != nil; req = req.Response.Request {
+		r.Redirects = append([]string{req.Response.Request.URL.String()}, r.Redirects...)
+	}
+
+	for _, h := range GatewayResponseHeaders {
+		if values := resp.Header.Values(h); len(values) > 0 {
+			if r.Headers == nil {
+				r.Headers = map[string]string{}
+			}
+			r.Headers[h] = strings.Join(values, ", ")
+		}
+	}
+
+	if resp.TLS != nil {
+		r.TLSVersion = tlsVersions[resp.TLS.Version]
+		if r.TLSVersion == "" {
+			r.TLSVersion = fmt.Sprintf("0x%04x", resp.TLS.Version)
+		}
+	}
+
+	return r
 }
 
-func (g *Gateway) Backoff(ctx context.Context) backoff.BackOff {
-	bo := &backoff.ExponentialBackOff{
-		InitialInterval:     30 * time.Second,
-		RandomizationFactor: 0.5,
-		Multiplier:          1.2,
-		MaxInterval:         2 * time.Minute,
-		MaxElapsedTime:      10 * time.Minute,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
+// url constructs the URL at which the given CID is requested from the gateway.
+func (g *Gateway) url(c cid.Cid) (string, error) {
+	if g.subdomain {
+		c = cid.NewCidV1(c.Type(), c.Hash())
+	}
+
+	u := strings.ReplaceAll(g.urlFmt, GatewayURLReplaceStr, c.String())
+
+	var format string
+	switch g.mode {
+	case GatewayModeRaw:
+		format = "raw"
+	case GatewayModeCAR:
+		format = "car"
+	default:
+		return u, nil
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", err
 	}
-	return backoff.WithContext(bo, ctx)
+
+	q := parsed.Query()
+	q.Set("format", format)
+	parsed.RawQuery = q.Encode()
+
+	return parsed.String(), nil
 }
 
-func (g *Gateway) Rate() time.Duration {
-	return 2 * time.Minute
+// verifyRawBlock checks that the given data hashes to the given CID.
+func verifyRawBlock(c cid.Cid, data []byte) error {
+	sum, err := c.Prefix().Sum(data)
+	if err != nil {
+		return errors.Wrap(err, "hash block")
+	}
+
+	if !bytes.Equal(sum.Hash(), c.Hash()) {
+		return errors.Wrapf(ErrCorruptedContent, "block hashes to %s instead of %s", sum, c)
+	}
+
+	return nil
 }
 
-func (g *Gateway) Timeout() time.Duration {
-	return 11 * time.Minute
+// verifyCAR checks that the given CAR file has the given CID as its root, that all contained blocks
+// hash to their CIDs, and that the block of the given CID is part of it. It returns the data of that block.
+func verifyCAR(c cid.Cid, data []byte) ([]byte, error) {
+	cr, err := car.NewCarReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(ErrCorruptedContent, err.Error())
+	}
+
+	hasRoot := false
+	for _, root := range cr.Header.Roots {
+		if bytes.Equal(root.Hash(), c.Hash()) {
+			hasRoot = true
+			break
+		}
+	}
+	if !hasRoot {
+		return nil, errors.Wrapf(ErrCorruptedContent, "car roots %v don't contain %s", cr.Header.Roots, c)
+	}
+
+	var block []byte
+	for {
+		blk, err := cr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(ErrCorruptedContent, err.Error())
+		}
+
+		if err = verifyRawBlock(blk.Cid(), blk.RawData()); err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(blk.Cid().Hash(), c.Hash()) {
+			block = blk.RawData()
+		}
+	}
+
+	if block == nil {
+		return nil, errors.Wrapf(ErrCorruptedContent, "car doesn't contain block %s", c)
+	}
+
+	return block, nil
 }
 
 func (g *Gateway) Name() string {
@@ -0,0 +1,200 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+	"github.com/ipld/go-car/util"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/sink"
+)
+
+func testCAR(t *testing.T, root cid.Cid, blks ...blocks.Block) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, &buf))
+	for _, blk := range blks {
+		require.NoError(t, util.LdWrite(&buf, blk.Cid().Bytes(), blk.RawData()))
+	}
+	return buf.Bytes()
+}
+
+func TestNewGatewayTarget(t *testing.T) {
+	tests := []struct {
+		name          string
+		conf          config.Gateway
+		wantMode      string
+		wantSubdomain bool
+		wantErr       bool
+	}{
+		{name: "default", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}"}, wantMode: GatewayModePath},
+		{name: "raw", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}", Mode: "raw"}, wantMode: GatewayModeRaw},
+		{name: "upper case", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}", Mode: "CAR"}, wantMode: GatewayModeCAR},
+		{name: "subdomain", conf: config.Gateway{URL: "https://{cid}.ipfs.dweb.link", Mode: "accept-raw"}, wantMode: GatewayModeAcceptRaw, wantSubdomain: true},
+		{name: "unknown mode", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}", Mode: "tar"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw, err := NewGatewayTarget(http.DefaultClient, tt.conf, "")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMode, gw.mode)
+			assert.Equal(t, tt.wantSubdomain, gw.subdomain)
+		})
+	}
+}
+
+func TestGateway_url(t *testing.T) {
+	blk := blocks.NewBlock([]byte("antares"))
+	c := blk.Cid()
+	cv1 := cid.NewCidV1(c.Type(), c.Hash())
+
+	tests := []struct {
+		name string
+		conf config.Gateway
+		want string
+	}{
+		{name: "path", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}"}, want: "https://ipfs.io/ipfs/" + c.String()},
+		{name: "raw", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}", Mode: GatewayModeRaw}, want: "https://ipfs.io/ipfs/" + c.String() + "?format=raw"},
+		{name: "car with query", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}?foo=bar", Mode: GatewayModeCAR}, want: "https://ipfs.io/ipfs/" + c.String() + "?foo=bar&format=car"},
+		{name: "accept", conf: config.Gateway{URL: "https://ipfs.io/ipfs/{cid}", Mode: GatewayModeAcceptCAR}, want: "https://ipfs.io/ipfs/" + c.String()},
+		{name: "subdomain", conf: config.Gateway{URL: "https://{cid}.ipfs.dweb.link/", Mode: GatewayModeRaw}, want: "https://" + cv1.String() + ".ipfs.dweb.link/?format=raw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw, err := NewGatewayTarget(http.DefaultClient, tt.conf, "")
+			require.NoError(t, err)
+
+			got, err := gw.url(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGateway_Operation(t *testing.T) {
+	key := testPrivKey(t)
+	signer, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	pl, err := NewPayload(key)
+	require.NoError(t, err)
+	data, err := pl.Bytes()
+	require.NoError(t, err)
+	jsonData, err := pl.JsonBytes()
+	require.NoError(t, err)
+
+	foreign, err := NewPayload(testPrivKey(t))
+	require.NoError(t, err)
+	foreignData, err := foreign.JsonBytes()
+	require.NoError(t, err)
+
+	staleData, err := testStalePayload(t, key).JsonBytes()
+	require.NoError(t, err)
+
+	blk := blocks.NewBlock(data)
+	other := blocks.NewBlock([]byte("other"))
+	corrupt, err := blocks.NewBlockWithCid([]byte("corrupt"), blk.Cid())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		mode       string
+		body       []byte
+		wantAccept string
+		wantFormat string
+		wantErr    error
+	}{
+		{name: "path", mode: GatewayModePath, body: jsonData},
+		{name: "path garbage", mode: GatewayModePath, body: []byte("garbage"), wantErr: ErrCorruptedContent},
+		{name: "path foreign signature", mode: GatewayModePath, body: foreignData, wantErr: ErrCorruptedContent},
+		{name: "path stale", mode: GatewayModePath, body: staleData, wantErr: ErrStaleContent},
+		{name: "raw", mode: GatewayModeRaw, body: data, wantFormat: "raw"},
+		{name: "raw corrupted", mode: GatewayModeRaw, body: []byte("corrupt"), wantFormat: "raw", wantErr: ErrCorruptedContent},
+		{name: "accept raw", mode: GatewayModeAcceptRaw, body: data, wantAccept: ContentTypeRaw},
+		{name: "car", mode: GatewayModeCAR, body: testCAR(t, blk.Cid(), blk), wantFormat: "car"},
+		{name: "car wrong root", mode: GatewayModeCAR, body: testCAR(t, other.Cid(), blk), wantFormat: "car", wantErr: ErrCorruptedContent},
+		{name: "car missing block", mode: GatewayModeCAR, body: testCAR(t, blk.Cid(), other), wantFormat: "car", wantErr: ErrCorruptedContent},
+		{name: "car corrupted block", mode: GatewayModeCAR, body: testCAR(t, blk.Cid(), corrupt), wantFormat: "car", wantErr: ErrCorruptedContent},
+		{name: "accept car", mode: GatewayModeAcceptCAR, body: testCAR(t, blk.Cid(), blk), wantAccept: ContentTypeCAR},
+		{name: "accept car garbage", mode: GatewayModeAcceptCAR, body: []byte("garbage"), wantAccept: ContentTypeCAR, wantErr: ErrCorruptedContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				_, _ = w.Write(tt.body)
+			}))
+			defer ts.Close()
+
+			gw, err := NewGatewayTarget(http.DefaultClient, config.Gateway{Name: "test", URL: ts.URL + "/ipfs/{cid}", Mode: tt.mode}, signer)
+			require.NoError(t, err)
+
+			ctx := withRetrieval(context.Background(), newRetrieval(&sink.Probe{StartedAt: time.Now()}))
+			err = gw.Operation(ctx, blk.Cid())
+			if tt.wantErr != nil {
+				var permanent *backoff.PermanentError
+				assert.ErrorAs(t, err, &permanent)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NotNil(t, req)
+			assert.True(t, strings.HasSuffix(req.URL.Path, blk.Cid().String()))
+			assert.Equal(t, tt.wantAccept, req.Header.Get("Accept"))
+			assert.Equal(t, tt.wantFormat, req.URL.Query().Get("format"))
+		})
+	}
+}
+
+func TestGateway_OperationRecordsResponse(t *testing.T) {
+	c := testCid(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ipfs/"+c.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/ipfs/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "nginx")
+		w.Header().Add("Via", "1.1 varnish")
+		w.Header().Add("Via", "1.1 cloudfront")
+		w.Header().Set("X-Unrelated", "value")
+		w.WriteHeader(http.StatusGatewayTimeout)
+		_, _ = w.Write([]byte("timeout"))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	gw, err := NewGatewayTarget(http.DefaultClient, config.Gateway{Name: "test", URL: ts.URL + "/redirect/{cid}"}, "")
+	require.NoError(t, err)
+
+	rtv := newRetrieval(&sink.Probe{StartedAt: time.Now()})
+	err = gw.Operation(withRetrieval(context.Background(), rtv), c)
+	assert.Error(t, err)
+
+	resp := rtv.Response()
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	assert.Equal(t, ts.URL+"/ipfs/"+c.String(), resp.URL)
+	assert.Equal(t, []string{ts.URL + "/redirect/" + c.String()}, resp.Redirects)
+	assert.Equal(t, map[string]string{"Server": "nginx", "Via": "1.1 varnish, 1.1 cloudfront"}, resp.Headers)
+	assert.Empty(t, resp.TLSVersion)
+	assert.Equal(t, int64(len("timeout")), resp.Bytes)
+}
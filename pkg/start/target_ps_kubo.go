@@ -0,0 +1,163 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+// KuboTargetName is the name of the target that pins content via the Kubo RPC API:
+// https://docs.ipfs.tech/reference/kubo/rpc/
+const KuboTargetName = "kubo"
+
+// KuboDefaults are the probe settings of a Kubo RPC target if not configured otherwise.
+var KuboDefaults = config.Probe{
+	Rate:    5 * time.Minute,
+	Timeout: 10 * time.Minute,
+	Backoff: config.Backoff{
+		InitialInterval:     time.Minute,
+		RandomizationFactor: utils.Ptr(0.5),
+		Multiplier:          1.2,
+		MaxInterval:         5 * time.Minute,
+		MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+	},
+}
+
+// The authorization schemes that the Kubo RPC target supports.
+const (
+	AuthSchemeNone   = "none"
+	AuthSchemeBasic  = "basic"
+	AuthSchemeBearer = "bearer"
+)
+
+// Kubo is a generic target for any service that exposes the Kubo RPC API, like
+// Kubo nodes, IPFS Cluster proxy endpoints or hosted Kubo APIs.
+type Kubo struct {
+	probeSettings
+	name       string
+	endpoint   string
+	client     *http.Client
+	authorize  func(req *http.Request)
+	pinOptions map[string]string
+}
+
+func NewKubo(h host.Host, client *http.Client, conf config.PinningService) (PinTarget, error) {
+	if conf.Endpoint == "" {
+		return nil, fmt.Errorf("missing kubo rpc endpoint")
+	}
+
+	name := conf.Name
+	if name == "" {
+		name = KuboTargetName
+	}
+
+	var authorize func(req *http.Request)
+	switch strings.ToLower(conf.AuthScheme) {
+	case "", AuthSchemeNone:
+		authorize = func(req *http.Request) {}
+	case AuthSchemeBasic:
+		username, password, found := strings.Cut(conf.Authorization, ":")
+		if !found {
+			return nil, fmt.Errorf("malformed basic auth credentials, expected username:password")
+		}
+		authorize = func(req *http.Request) { req.SetBasicAuth(username, password) }
+	case AuthSchemeBearer:
+		authorize = func(req *http.Request) { req.Header.Add("Authorization", "Bearer "+conf.Authorization) }
+	default:
+		return nil, fmt.Errorf("unknown auth scheme %s", conf.AuthScheme)
+	}
+
+	return &Kubo{
+		probeSettings: newProbeSettings(conf.Probe, KuboDefaults),
+		name:          name,
+		endpoint:      strings.TrimSuffix(conf.Endpoint, "/"),
+		client:        client,
+		authorize:     authorize,
+		pinOptions:    conf.PinOptions,
+	}, nil
+}
+
+var _ PinTarget = (*Kubo)(nil)
+
+// Operation pins the given CID. Kubo only responds after it has fetched the content.
+func (k *Kubo) Operation(ctx context.Context, c cid.Cid) error {
+	logEntry := k.logEntry().WithField("cid", c)
+	logEntry.Infoln("Pinning cid to Kubo...")
+
+	respBody, err := k.do(ctx, "pin/add", c, k.pinOptions)
+	if err != nil {
+		return errors.Wrap(err, "pin cid")
+	}
+	logEntry.Debugln("Pin response:", string(respBody))
+
+	return nil
+}
+
+func (k *Kubo) CleanUp(ctx context.Context, c cid.Cid) error {
+	logEntry := k.logEntry().WithField("cid", c)
+	logEntry.Debugln("Unpinning cid from Kubo...")
+
+	respBody, err := k.do(ctx, "pin/rm", c, nil)
+	if err != nil {
+		return errors.Wrap(err, "unpin cid")
+	}
+	logEntry.Debugln("Unpin response:", string(respBody))
+
+	return nil
+}
+
+// do calls the given RPC command with the given CID as argument and returns the response body.
+func (k *Kubo) do(ctx context.Context, cmd string, c cid.Cid, options map[string]string) ([]byte, error) {
+	query := url.Values{}
+	for key, value := range options {
+		query.Set(key, value)
+	}
+	query.Set("arg", "/ipfs/"+c.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.endpoint+"/api/v0/"+cmd+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+	k.authorize(req)
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "request do")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response body")
+	}
+
+	if !utils.IsSuccessStatusCode(resp) {
+		return nil, fmt.Errorf("status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return respBody, nil
+}
+
+func (k *Kubo) Name() string {
+	return k.name
+}
+
+func (k *Kubo) Type() string {
+	return "pinning-service"
+}
+
+func (k *Kubo) logEntry() *log.Entry {
+	return log.WithField("type", k.Type()).WithField("name", k.Name())
+}
@@ -0,0 +1,106 @@
+package start
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+func setupKubo(t *testing.T, conf config.PinningService) (PinTarget, *[]*http.Request) {
+	var requests []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Pins":["` + r.URL.Query().Get("arg") + `"]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	conf.Target = KuboTargetName
+	conf.Endpoint = ts.URL
+	target, err := NewKubo(nil, http.DefaultClient, conf)
+	require.NoError(t, err)
+
+	return target, &requests
+}
+
+func TestNewKubo(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    config.PinningService
+		wantErr bool
+	}{
+		{name: "no endpoint", conf: config.PinningService{}, wantErr: true},
+		{name: "no auth", conf: config.PinningService{Endpoint: "http://localhost:5001"}},
+		{name: "none auth", conf: config.PinningService{Endpoint: "http://localhost:5001", AuthScheme: "none"}},
+		{name: "basic auth", conf: config.PinningService{Endpoint: "http://localhost:5001", AuthScheme: "basic", Authorization: "user:pass"}},
+		{name: "malformed basic auth", conf: config.PinningService{Endpoint: "http://localhost:5001", AuthScheme: "basic", Authorization: "user"}, wantErr: true},
+		{name: "bearer auth", conf: config.PinningService{Endpoint: "http://localhost:5001", AuthScheme: "Bearer", Authorization: "token"}},
+		{name: "unknown auth", conf: config.PinningService{Endpoint: "http://localhost:5001", AuthScheme: "digest"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKubo(nil, http.DefaultClient, tt.conf)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKubo_Operation(t *testing.T) {
+	target, requests := setupKubo(t, config.PinningService{
+		Name:          "my-node",
+		AuthScheme:    AuthSchemeBasic,
+		Authorization: "user:pass",
+		PinOptions:    map[string]string{"recursive": "false"},
+	})
+	c := testCid(t)
+
+	assert.Equal(t, "my-node", target.Name())
+	require.NoError(t, target.Operation(context.Background(), c))
+	require.NoError(t, target.(CleanupTarget).CleanUp(context.Background(), c))
+	require.Len(t, *requests, 2)
+
+	pin := (*requests)[0]
+	assert.Equal(t, "/api/v0/pin/add", pin.URL.Path)
+	assert.Equal(t, url.Values{"arg": {"/ipfs/" + c.String()}, "recursive": {"false"}}, pin.URL.Query())
+	username, password, ok := pin.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+
+	unpin := (*requests)[1]
+	assert.Equal(t, "/api/v0/pin/rm", unpin.URL.Path)
+	assert.Equal(t, url.Values{"arg": {"/ipfs/" + c.String()}}, unpin.URL.Query())
+}
+
+func TestKubo_Operation_bearer(t *testing.T) {
+	target, requests := setupKubo(t, config.PinningService{AuthScheme: AuthSchemeBearer, Authorization: "token"})
+
+	require.NoError(t, target.Operation(context.Background(), testCid(t)))
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "Bearer token", (*requests)[0].Header.Get("Authorization"))
+	assert.Equal(t, KuboTargetName, target.Name())
+}
+
+func TestNewInfura(t *testing.T) {
+	_, err := NewInfura(nil, http.DefaultClient, config.PinningService{Target: InfuraTargetName, Authorization: "project"})
+	assert.Error(t, err)
+
+	target, err := NewInfura(nil, http.DefaultClient, config.PinningService{Target: InfuraTargetName, Authorization: "project,secret"})
+	require.NoError(t, err)
+	assert.Equal(t, InfuraTargetName, target.Name())
+	assert.Equal(t, InfuraEndpoint, target.(*Kubo).endpoint)
+}
@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/host"
 	ma "github.com/multiformats/go-multiaddr"
@@ -17,18 +16,43 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dennis-tra/antares/pkg/config"
 	"github.com/dennis-tra/antares/pkg/utils"
 )
 
 const PinataTargetName = "pinata"
 
+// PinataDefaults are the probe settings of Pinata if not configured otherwise.
+var PinataDefaults = config.Probe{
+	Rate:    5 * time.Minute,
+	Timeout: 10 * time.Minute,
+	Backoff: config.Backoff{
+		InitialInterval:     time.Minute,
+		RandomizationFactor: utils.Ptr(0.5),
+		Multiplier:          1.2,
+		MaxInterval:         5 * time.Minute,
+		MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+	},
+}
+
 type Pinata struct {
-	h    host.Host
-	auth string
+	probeSettings
+	h      host.Host
+	client *http.Client
+	auth   string
 }
 
-func NewPinata(h host.Host, auth string) (PinTarget, error) {
-	return &Pinata{h: h, auth: auth}, nil
+func NewPinata(h host.Host, client *http.Client, conf config.PinningService) (PinTarget, error) {
+	if conf.Authorization == "" {
+		return nil, fmt.Errorf("missing pinata jwt")
+	}
+
+	return &Pinata{
+		probeSettings: newProbeSettings(conf.Probe, PinataDefaults),
+		h:             h,
+		client:        client,
+		auth:          conf.Authorization,
+	}, nil
 }
 
 var _ PinTarget = (*Pinata)(nil)
@@ -70,7 +94,7 @@ func (p *Pinata) Operation(ctx context.Context, c cid.Cid) error {
 	req.Header.Add("Authorization", "Bearer "+p.auth)
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := p.client.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "pin file to pinata")
 	}
@@ -103,27 +127,6 @@ type PinataOptions struct {
 	HostNodes []string `json:"hostNodes"`
 }
 
-func (p *Pinata) Backoff(ctx context.Context) backoff.BackOff {
-	bo := &backoff.ExponentialBackOff{
-		InitialInterval:     time.Minute,
-		RandomizationFactor: 0.5,
-		Multiplier:          1.2,
-		MaxInterval:         5 * time.Minute,
-		MaxElapsedTime:      10 * time.Minute,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
-	}
-	return backoff.WithContext(bo, ctx)
-}
-
-func (p *Pinata) Rate() time.Duration {
-	return 5 * time.Minute
-}
-
-func (p *Pinata) Timeout() time.Duration {
-	return 10 * time.Minute
-}
-
 func (p *Pinata) Name() string {
 	return "pinata"
 }
@@ -143,7 +146,7 @@ func (p *Pinata) CleanUp(ctx context.Context, c cid.Cid) error {
 	}
 	req.Header.Add("Authorization", "Bearer "+p.auth)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := p.client.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "unpin cid from pinata")
 	}
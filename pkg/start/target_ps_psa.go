@@ -0,0 +1,246 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/host"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+// PSATargetName is the name of the target that implements the IPFS Pinning Service API:
+// https://ipfs.github.io/pinning-services-api-spec/
+const PSATargetName = "psa"
+
+// PSADefaults are the probe settings of a pinning service API target if not configured otherwise.
+var PSADefaults = config.Probe{
+	Rate:    5 * time.Minute,
+	Timeout: 10 * time.Minute,
+	Backoff: config.Backoff{
+		InitialInterval:     time.Minute,
+		RandomizationFactor: utils.Ptr(0.5),
+		Multiplier:          1.2,
+		MaxInterval:         5 * time.Minute,
+		MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+	},
+}
+
+// PSAPollInterval is the interval at which the status of a pin request is polled.
+var PSAPollInterval = 10 * time.Second
+
+// The pin request states of the pinning service API.
+const (
+	PSAStatusQueued  = "queued"
+	PSAStatusPinning = "pinning"
+	PSAStatusPinned  = "pinned"
+	PSAStatusFailed  = "failed"
+)
+
+// PSA is a generic target for pinning services that implement the IPFS Pinning Service API.
+type PSA struct {
+	probeSettings
+	h        host.Host
+	name     string
+	endpoint string
+	client   *http.Client
+	token    string
+
+	// requestIDs keeps track of all pin requests that were created for a CID,
+	// so that they can be removed again in CleanUp.
+	requestIDsLk sync.Mutex
+	requestIDs   map[cid.Cid][]string
+}
+
+func NewPSA(h host.Host, client *http.Client, conf config.PinningService) (PinTarget, error) {
+	if conf.Endpoint == "" {
+		return nil, fmt.Errorf("missing pinning service api endpoint")
+	}
+
+	name := conf.Name
+	if name == "" {
+		name = PSATargetName
+	}
+
+	return &PSA{
+		probeSettings: newProbeSettings(conf.Probe, PSADefaults),
+		h:             h,
+		name:          name,
+		endpoint:      strings.TrimSuffix(conf.Endpoint, "/"),
+		client:        client,
+		token:         conf.Authorization,
+		requestIDs:    map[cid.Cid][]string{},
+	}, nil
+}
+
+var _ PinTarget = (*PSA)(nil)
+
+// Operation creates a new pin request for the given CID and polls its status until
+// the service reports that the CID was pinned.
+func (p *PSA) Operation(ctx context.Context, c cid.Cid) error {
+	logEntry := p.logEntry().WithField("cid", c)
+	logEntry.Infoln("Pinning cid to pinning service...")
+
+	payload := PSAPin{
+		CID:     c.String(),
+		Name:    "Antares " + time.Now().UTC().Format(time.RFC3339),
+		Origins: p.origins(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal request payload")
+	}
+
+	status, err := p.do(ctx, http.MethodPost, "/pins", data)
+	if err != nil {
+		return errors.Wrap(err, "create pin request")
+	}
+	logEntry = logEntry.WithField("requestID", status.RequestID)
+
+	p.requestIDsLk.Lock()
+	p.requestIDs[c] = append(p.requestIDs[c], status.RequestID)
+	p.requestIDsLk.Unlock()
+
+	for {
+		logEntry.WithField("status", status.Status).WithField("delegates", status.Delegates).Debugln("Pin request status")
+
+		switch status.Status {
+		case PSAStatusPinned:
+			return nil
+		case PSAStatusFailed:
+			return fmt.Errorf("pin request %s failed", status.RequestID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(PSAPollInterval):
+		}
+
+		if status, err = p.do(ctx, http.MethodGet, "/pins/"+status.RequestID, nil); err != nil {
+			return errors.Wrap(err, "get pin request status")
+		}
+	}
+}
+
+// CleanUp removes all pin requests that were created for the given CID.
+func (p *PSA) CleanUp(ctx context.Context, c cid.Cid) error {
+	logEntry := p.logEntry().WithField("cid", c)
+	logEntry.Infoln("Removing pin requests from pinning service...")
+
+	p.requestIDsLk.Lock()
+	requestIDs := p.requestIDs[c]
+	p.requestIDsLk.Unlock()
+
+	for len(requestIDs) > 0 {
+		if _, err := p.do(ctx, http.MethodDelete, "/pins/"+requestIDs[0], nil); err != nil {
+			// Remember the pin requests that still need to be removed in case of a retry
+			p.requestIDsLk.Lock()
+			p.requestIDs[c] = requestIDs
+			p.requestIDsLk.Unlock()
+			return errors.Wrap(err, "remove pin request")
+		}
+		requestIDs = requestIDs[1:]
+	}
+
+	p.requestIDsLk.Lock()
+	delete(p.requestIDs, c)
+	p.requestIDsLk.Unlock()
+
+	return nil
+}
+
+// do sends a request with the given method and body to the given path of the pinning service API.
+// It returns the decoded pin status of the response. For DELETE requests the pin status is nil.
+func (p *PSA) do(ctx context.Context, method string, path string, body []byte) (*PSAPinStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+	// Self-hosted services may not require an access token
+	if p.token != "" {
+		req.Header.Add("Authorization", "Bearer "+p.token)
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "request do")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response body")
+	}
+	p.logEntry().WithField("method", method).WithField("path", path).Debugln("Response:", string(respBody))
+
+	if !utils.IsSuccessStatusCode(resp) {
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	if method == http.MethodDelete {
+		return nil, nil
+	}
+
+	status := &PSAPinStatus{}
+	if err = json.Unmarshal(respBody, status); err != nil {
+		return nil, errors.Wrap(err, "unmarshal pin status")
+	}
+
+	return status, nil
+}
+
+// origins returns the public multi addresses of the Antares host, so that the pinning
+// service can directly connect to it.
+func (p *PSA) origins() []string {
+	origins := []string{}
+	for _, maddr := range p.h.Addrs() {
+		if manet.IsPublicAddr(maddr) {
+			origins = append(origins, maddr.String()+"/p2p/"+p.h.ID().String())
+		}
+	}
+	return origins
+}
+
+func (p *PSA) Name() string {
+	return p.name
+}
+
+func (p *PSA) Type() string {
+	return "pinning-service"
+}
+
+func (p *PSA) logEntry() *log.Entry {
+	return log.WithField("type", p.Type()).WithField("name", p.Name())
+}
+
+// PSAPin is the pin object of the pinning service API.
+type PSAPin struct {
+	CID     string   `json:"cid"`
+	Name    string   `json:"name,omitempty"`
+	Origins []string `json:"origins,omitempty"`
+}
+
+// PSAPinStatus is the pin status object of the pinning service API.
+type PSAPinStatus struct {
+	RequestID string   `json:"requestid"`
+	Status    string   `json:"status"`
+	Delegates []string `json:"delegates"`
+	Pin       PSAPin   `json:"pin"`
+}
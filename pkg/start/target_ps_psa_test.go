@@ -0,0 +1,171 @@
+package start
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+// psaServer is a minimal in-memory implementation of the pinning service API.
+type psaServer struct {
+	t      *testing.T
+	token  string
+	polls  int
+	mu     sync.Mutex
+	pins   map[string]*PSAPinStatus
+	status []string
+}
+
+func (s *psaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	authorization := ""
+	if s.token != "" {
+		authorization = "Bearer " + s.token
+	}
+	if r.Header.Get("Authorization") != authorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/pins":
+		pin := PSAPin{}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&pin))
+		status := &PSAPinStatus{RequestID: "req-" + pin.CID, Status: PSAStatusQueued, Pin: pin}
+		s.pins[status.RequestID] = status
+		w.WriteHeader(http.StatusAccepted)
+		require.NoError(s.t, json.NewEncoder(w).Encode(status))
+	case r.Method == http.MethodGet:
+		status, ok := s.pins[r.URL.Path[len("/pins/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if s.polls < len(s.status) {
+			status.Status = s.status[s.polls]
+		}
+		s.polls += 1
+		require.NoError(s.t, json.NewEncoder(w).Encode(status))
+	case r.Method == http.MethodDelete:
+		requestID := r.URL.Path[len("/pins/"):]
+		if _, ok := s.pins[requestID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.pins, requestID)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func setupPSA(t *testing.T, token string, status ...string) (*PSA, *psaServer) {
+	tmpPollInterval := PSAPollInterval
+	PSAPollInterval = 10 * time.Millisecond
+
+	srv := &psaServer{t: t, token: "secret", pins: map[string]*PSAPinStatus{}, status: status}
+	ts := httptest.NewServer(srv)
+
+	h, err := libp2p.New(libp2p.NoListenAddrs)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		PSAPollInterval = tmpPollInterval
+		ts.Close()
+		_ = h.Close()
+	})
+
+	target, err := NewPSA(h, http.DefaultClient, config.PinningService{Target: PSATargetName, Endpoint: ts.URL + "/", Authorization: token})
+	require.NoError(t, err)
+
+	return target.(*PSA), srv
+}
+
+func testCid(t *testing.T) cid.Cid {
+	mh, err := multihash.Sum([]byte(t.Name()), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+	return cid.NewCidV1(cid.Raw, mh)
+}
+
+func TestNewPSA(t *testing.T) {
+	_, err := NewPSA(nil, http.DefaultClient, config.PinningService{Target: PSATargetName})
+	assert.Error(t, err)
+
+	target, err := NewPSA(nil, http.DefaultClient, config.PinningService{Target: PSATargetName, Endpoint: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, PSATargetName, target.Name())
+
+	target, err = NewPSA(nil, http.DefaultClient, config.PinningService{Target: PSATargetName, Name: "filebase", Endpoint: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "filebase", target.Name())
+	assert.Equal(t, PSADefaults.Rate, target.Rate())
+}
+
+func TestPSA_Operation_pinned(t *testing.T) {
+	target, srv := setupPSA(t, "secret", PSAStatusPinning, PSAStatusPinned)
+	c := testCid(t)
+
+	err := target.Operation(context.Background(), c)
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.polls)
+	assert.Contains(t, srv.pins, "req-"+c.String())
+	assert.Equal(t, c.String(), srv.pins["req-"+c.String()].Pin.CID)
+
+	pinned, err := time.Parse(time.RFC3339, strings.TrimPrefix(srv.pins["req-"+c.String()].Pin.Name, "Antares "))
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, pinned.Location())
+
+	err = target.CleanUp(context.Background(), c)
+	require.NoError(t, err)
+	assert.Empty(t, srv.pins)
+	assert.Empty(t, target.requestIDs)
+}
+
+func TestPSA_Operation_failed(t *testing.T) {
+	target, _ := setupPSA(t, "secret", PSAStatusFailed)
+
+	err := target.Operation(context.Background(), testCid(t))
+	assert.Error(t, err)
+}
+
+func TestPSA_Operation_unauthorized(t *testing.T) {
+	target, srv := setupPSA(t, "wrong")
+
+	err := target.Operation(context.Background(), testCid(t))
+	assert.Error(t, err)
+	assert.Empty(t, srv.pins)
+}
+
+func TestPSA_Operation_noToken(t *testing.T) {
+	target, srv := setupPSA(t, "", PSAStatusPinned)
+	srv.token = ""
+
+	err := target.Operation(context.Background(), testCid(t))
+	require.NoError(t, err)
+	assert.Len(t, srv.pins, 1)
+}
+
+func TestPSA_Operation_canceled(t *testing.T) {
+	target, _ := setupPSA(t, "secret")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := target.Operation(ctx, testCid(t))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"github.com/cenkalti/backoff/v4"
+	"fmt"
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -17,38 +19,41 @@ import (
 
 const Web3TargetName = "web3"
 
-type Web3 struct {
-	h    host.Host
-	auth string
-}
-
-func NewWeb3(h host.Host, auth string) (UploadTarget, error) {
-	return &Web3{h: h, auth: auth}, nil
-}
-
-var _ UploadTarget = (*Web3)(nil)
-
-func (t *Web3) Backoff(ctx context.Context) backoff.BackOff {
-	bo := &backoff.ExponentialBackOff{
+// Web3Defaults are the probe settings of web3.storage if not configured otherwise.
+var Web3Defaults = config.Probe{
+	Rate:    2 * time.Minute,
+	Timeout: 10 * time.Minute,
+	Backoff: config.Backoff{
 		InitialInterval:     time.Minute,
-		RandomizationFactor: 0.5,
+		RandomizationFactor: utils.Ptr(0.5),
 		Multiplier:          1.2,
 		MaxInterval:         5 * time.Minute,
-		MaxElapsedTime:      10 * time.Minute,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
-	}
-	return backoff.WithContext(bo, ctx)
+		MaxElapsedTime:      utils.Ptr(10 * time.Minute),
+	},
 }
 
-func (t *Web3) Rate() time.Duration {
-	return 2 * time.Minute
+type Web3 struct {
+	probeSettings
+	h      host.Host
+	client *http.Client
+	auth   string
 }
 
-func (t *Web3) Timeout() time.Duration {
-	return 10 * time.Minute
+func NewWeb3(h host.Host, client *http.Client, conf config.UploadService) (UploadTarget, error) {
+	if conf.Authorization == "" {
+		return nil, fmt.Errorf("missing web3.storage api token")
+	}
+
+	return &Web3{
+		probeSettings: newProbeSettings(conf.Probe, Web3Defaults),
+		h:             h,
+		client:        client,
+		auth:          conf.Authorization,
+	}, nil
 }
 
+var _ UploadTarget = (*Web3)(nil)
+
 func (t *Web3) Name() string {
 	return "web3"
 }
@@ -73,7 +78,7 @@ func (t *Web3) UploadContent(ctx context.Context, block *blocks.BasicBlock) erro
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-NAME", "antares-test-file")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := t.client.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "upload file to web3.storage")
 	}
@@ -1,6 +1,9 @@
 package start
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Throttle struct {
 	C    <-chan time.Time // The channel on which the leases are delivered.
@@ -12,12 +15,13 @@ type Throttle struct {
 // for slow receivers. The duration must be greater than zero; if not, NewThrottle will
 // panic. Stop the throttle to release associated resources and close its channel.
 func NewThrottle(x int, duration time.Duration) *Throttle {
+	// Create the ticker before starting the go routine, so that an invalid duration panics in the caller
+	ticker := time.NewTicker(duration)
+
 	ch := make(chan time.Time, x-1)
 	done := make(chan struct{}, 1)
 
 	go func() {
-		ticker := time.NewTicker(duration)
-
 		now := time.Now()
 
 		for i := 0; i < x; i++ {
@@ -52,3 +56,38 @@ func (t *Throttle) Stop() {
 	default:
 	}
 }
+
+// probeSlots limits the number of probes that are in flight at the same time. A nil
+// value doesn't limit the number of probes.
+type probeSlots chan struct{}
+
+// newProbeSlots returns probe slots that allow up to n probes in flight at the
+// same time. If n is zero or negative, the number of probes is not limited.
+func newProbeSlots(n int) probeSlots {
+	if n <= 0 {
+		return nil
+	}
+	return make(probeSlots, n)
+}
+
+// acquire waits until a slot is free and takes it. It returns false if the
+// context was canceled before.
+func (s probeSlots) acquire(ctx context.Context) bool {
+	if s == nil {
+		return true
+	}
+
+	select {
+	case s <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot that was previously acquired.
+func (s probeSlots) release() {
+	if s != nil {
+		<-s
+	}
+}
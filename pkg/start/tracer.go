@@ -2,75 +2,154 @@ package start
 
 import (
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-bitswap/message"
+	pb "github.com/ipfs/go-bitswap/message/pb"
 	"github.com/ipfs/go-bitswap/tracer"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 	log "github.com/sirupsen/logrus"
 )
 
+// wantBufferSize is the number of distinct peers that can want a registered CID before
+// the tracer starts dropping them because the probe can't keep up.
+const wantBufferSize = 100
+
+// Want captures the first Bitswap want of a peer for a registered CID and the subsequent
+// Bitswap interactions with that peer for the same CID. The fields CanceledAt and BlockSentAt
+// are updated by the Tracer and must only be read after the CID was unregistered.
+type Want struct {
+	PeerID       peer.ID
+	WantType     pb.Message_Wantlist_WantType
+	Priority     int32
+	SendDontHave bool
+	SeenAt       time.Time
+
+	// CanceledAt is the time at which the peer canceled its want (zero if it didn't).
+	CanceledAt time.Time
+
+	// BlockSentAt is the time at which we sent the block to the peer (zero if we didn't).
+	BlockSentAt time.Time
+}
+
+// registration holds the state for a single registered CID.
+type registration struct {
+	ch    chan *Want
+	wants map[peer.ID]*Want
+}
+
+// The Tracer matches Bitswap messages against registered CIDs. CIDs are matched by their multihash, so that
+// requests for a different CID version of the same content (e.g., CIDv1 for subdomain gateways) are also detected.
 type Tracer struct {
-	cidsLk sync.RWMutex
-	cids   map[string]chan<- peer.ID
+	cidsLk sync.Mutex
+	cids   map[string]*registration
 }
 
 var _ tracer.Tracer = (*Tracer)(nil)
 
 func NewTracer() *Tracer {
 	return &Tracer{
-		cidsLk: sync.RWMutex{},
-		cids:   map[string]chan<- peer.ID{},
+		cidsLk: sync.Mutex{},
+		cids:   map[string]*registration{},
 	}
 }
 
-func (t *Tracer) Register(contentID cid.Cid) <-chan peer.ID {
+// Register starts tracking Bitswap wants for the given CID. The returned channel receives the first want of each
+// distinct peer until the CID is unregistered again.
+func (t *Tracer) Register(contentID cid.Cid) <-chan *Want {
 	log.WithField("cid", contentID).Debugln("Tracer registered CID")
 
 	t.cidsLk.Lock()
 	defer t.cidsLk.Unlock()
 
-	ch := make(chan peer.ID)
-	t.cids[string(contentID.Bytes())] = ch
+	reg := &registration{
+		ch:    make(chan *Want, wantBufferSize),
+		wants: map[peer.ID]*Want{},
+	}
+	t.cids[string(contentID.Hash())] = reg
 
-	return ch
+	return reg.ch
 }
 
+// Unregister stops tracking Bitswap wants for the given CID and closes the corresponding channel.
+// It is safe to call Unregister multiple times.
 func (t *Tracer) Unregister(contentID cid.Cid) {
 	t.cidsLk.Lock()
 	defer t.cidsLk.Unlock()
-	log.WithField("cid", contentID).Debugln("Tracer unregistered CID")
 
-	ch, ok := t.cids[string(contentID.Bytes())]
+	reg, ok := t.cids[string(contentID.Hash())]
 	if !ok {
 		return
 	}
+	log.WithField("cid", contentID).Debugln("Tracer unregistered CID")
 
-	close(ch)
-	delete(t.cids, string(contentID.Bytes()))
+	close(reg.ch)
+	delete(t.cids, string(contentID.Hash()))
 }
 
 func (t *Tracer) MessageReceived(id peer.ID, msg message.BitSwapMessage) {
 	log.WithField("peerID", id).WithField("size", msg.Size()).Traceln("Received Bitswap message")
 
-	t.cidsLk.RLock()
-	defer t.cidsLk.RUnlock()
+	t.cidsLk.Lock()
+	defer t.cidsLk.Unlock()
 
 	for _, e := range msg.Wantlist() {
-		ch, ok := t.cids[string(e.Cid.Bytes())]
+		reg, ok := t.cids[string(e.Cid.Hash())]
 		if !ok {
 			continue
 		}
 
+		logEntry := log.WithField("peerID", id).WithField("cid", e.Cid)
+		if want, seen := reg.wants[id]; seen {
+			if e.Cancel && want.CanceledAt.IsZero() {
+				want.CanceledAt = time.Now()
+				logEntry.Traceln("Tracer recorded cancel")
+			} else {
+				logEntry.Traceln("Tracer ignored repeated want")
+			}
+			continue
+		} else if e.Cancel {
+			logEntry.Traceln("Tracer ignored cancel without want")
+			continue
+		}
+
+		want := &Want{
+			PeerID:       id,
+			WantType:     e.WantType,
+			Priority:     e.Priority,
+			SendDontHave: e.SendDontHave,
+			SeenAt:       time.Now(),
+		}
+		// Only record delivered wants, so that a later want of the peer isn't ignored as repeated
 		select {
-		case ch <- id:
-			log.WithField("peerID", id).WithField("cid", e.Cid).Traceln("Tracer delivered matched message")
+		case reg.ch <- want:
+			reg.wants[id] = want
+			logEntry.Traceln("Tracer delivered matched message")
 		default:
-			log.WithField("peerID", id).WithField("cid", e.Cid).Traceln("Tracer dropped matched message")
+			logEntry.Warnln("Tracer dropped matched message")
 		}
 	}
 }
 
 func (t *Tracer) MessageSent(id peer.ID, msg message.BitSwapMessage) {
 	log.WithField("peerID", id).WithField("size", msg.Size()).Traceln("Sent Bitswap message")
+
+	t.cidsLk.Lock()
+	defer t.cidsLk.Unlock()
+
+	for _, b := range msg.Blocks() {
+		reg, ok := t.cids[string(b.Cid().Hash())]
+		if !ok {
+			continue
+		}
+
+		want, ok := reg.wants[id]
+		if !ok || !want.BlockSentAt.IsZero() {
+			continue
+		}
+
+		want.BlockSentAt = time.Now()
+		log.WithField("peerID", id).WithField("cid", b.Cid()).Traceln("Tracer recorded sent block")
+	}
 }
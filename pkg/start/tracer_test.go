@@ -0,0 +1,42 @@
+package start
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-bitswap/message"
+	pb "github.com/ipfs/go-bitswap/message/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracer_MessageReceived_dropped(t *testing.T) {
+	tr := NewTracer()
+	c := testCid(t)
+	wants := tr.Register(c)
+	defer tr.Unregister(c)
+
+	msg := message.New(false)
+	msg.AddEntry(c, 1, pb.Message_Wantlist_Have, true)
+
+	// Fill the buffer, so that the want of the next peer is dropped
+	for i := 0; i < wantBufferSize; i++ {
+		tr.MessageReceived(peer.ID(fmt.Sprintf("peer-%d", i)), msg)
+	}
+	tr.MessageReceived("dropped", msg)
+
+	for i := 0; i < wantBufferSize; i++ {
+		<-wants
+	}
+
+	// A later want of the peer whose want was dropped must be delivered
+	tr.MessageReceived("dropped", msg)
+	select {
+	case want := <-wants:
+		require.NotNil(t, want)
+		assert.Equal(t, peer.ID("dropped"), want.PeerID)
+	default:
+		t.Fatal("want of dropped peer wasn't delivered")
+	}
+}
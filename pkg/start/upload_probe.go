@@ -2,12 +2,14 @@ package start
 
 import (
 	"context"
-	"github.com/amit7itz/goset"
+	"sync/atomic"
+
 	"github.com/cenkalti/backoff/v4"
 	"github.com/dennis-tra/antares/pkg/config"
-	"github.com/dennis-tra/antares/pkg/db"
 	"github.com/dennis-tra/antares/pkg/maxmind"
 	"github.com/dennis-tra/antares/pkg/metrics"
+	"github.com/dennis-tra/antares/pkg/models"
+	"github.com/dennis-tra/antares/pkg/sink"
 	"github.com/dennis-tra/antares/pkg/utils"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
@@ -15,26 +17,26 @@ import (
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
-	ma "github.com/multiformats/go-multiaddr"
-	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/multiformats/go-multicodec"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
-	"sort"
 	"time"
 )
 
 type UploadProbe struct {
 	host       host.Host
-	dbc        *db.Client
+	sink       sink.Sink
 	dht        *kaddht.IpfsDHT
+	honeypot   *Honeypot
+	identifier *Identifier
 	mmc        *maxmind.Client
 	config     *config.Config
 	target     UploadTarget
 	probeCount int64
 	trackCount int64
+	slots      probeSlots
 	done       chan struct{}
 }
 
@@ -47,49 +49,38 @@ func (u *UploadProbe) run(ctx context.Context) {
 		return
 	}
 
-	// Only request
-	throttle := NewThrottle(1, u.target.Rate())
-	defer throttle.Stop()
-
-	for {
-		// Giving cancelled context precedence
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
-		u.logEntry().WithField("rate", u.target.Rate()).Infoln("Checking probe lease...")
-		select {
-		case <-ctx.Done():
-			return
-		case <-throttle.C:
-		}
-
-		err := u.probeTarget(ctx)
-		if utils.IsContextErr(err) {
-			return
-		} else if err != nil {
-			u.logEntry().WithError(err).Warnln("Error probing target")
-		}
-	}
+	runProbes(ctx, u.target, u.slots, u.logEntry(), u.probeTarget)
 }
 
 func (u *UploadProbe) probeTarget(ctx context.Context) error {
-	u.probeCount += 1
-	stats.Record(ctx, metrics.ProbeCount.M(u.probeCount))
+	stats.Record(ctx, metrics.ProbeCount.M(atomic.AddInt64(&u.probeCount, 1)))
 
 	block, err := u.generateContent(ctx)
 	if err != nil {
 		return errors.Wrap(err, "generate content")
 	}
-	u.logEntry().Infoln("foo")
-
-	tCtx, cancel := context.WithTimeout(ctx, u.target.Timeout())
 	logEntry := u.logEntry().WithField("cid", block.Cid().String())
 
+	probe, err := startProbe(ctx, u.sink, u.target, block.Cid())
+	if err != nil {
+		return errors.Wrap(err, "start probe")
+	}
+
+	chLookup := u.honeypot.Register(block.Cid())
+	defer u.honeypot.Unregister(block.Cid())
+
+	tCtx, cancel := context.WithTimeout(ctx, u.target.Timeout())
 	defer cancel()
 
+	res := &probeResult{}
+	defer finishProbe(ctx, u.sink, logEntry, probe, res)
+
+	peers := newProbePeers(u.host, block.Cid())
+	defer peers.unprotectAll()
+
+	opErr := make(chan error, 1)
+	opDuration := make(chan time.Duration, 1)
+	opStart := time.Now()
 	go func() {
 		logEntry.Infoln("Starting probe operation")
 
@@ -98,17 +89,29 @@ func (u *UploadProbe) probeTarget(ctx context.Context) error {
 		}
 		bo := u.target.Backoff(tCtx)
 
-		if err = backoff.RetryNotify(op, bo, u.notify); err != nil && !utils.IsContextErr(err) {
+		if err := backoff.RetryNotify(op, bo, u.notify); err != nil && !utils.IsContextErr(err) {
 			logEntry.Infoln("Probe operation failed")
+			opErr <- err
 			cancel()
+		} else if err == nil {
+			opDuration <- time.Since(opStart)
 		}
 	}()
-	defer cleanupProbe(tCtx, logEntry, u.target, block.Cid())
+	defer cleanupProbe(ctx, logEntry, u.target, block.Cid())
 
 	chProvider := u.dht.FindProvidersAsync(tCtx, block.Cid(), 0)
 	logEntry.Infoln("Finding providers for CID")
 
 	var foundProviders = false
+	defer func() {
+		res.setOperationDuration(opDuration)
+		if foundProviders {
+			res.outcome = models.ProbeOutcomeSuccess
+		} else {
+			res.setOutcome(ctx, opErr)
+		}
+	}()
+
 	for {
 		select {
 		case peer, more := <-chProvider:
@@ -126,10 +129,18 @@ func (u *UploadProbe) probeTarget(ctx context.Context) error {
 			}
 
 			foundProviders = true
+			peers.protect(peer.ID)
 
-			if err := u.trackProvider(ctx, peer); err != nil {
+			if err := u.trackProvider(ctx, probe, peer); err != nil {
 				return err
 			}
+		case lookup, more := <-chLookup:
+			if !more {
+				chLookup = nil
+				continue
+			}
+			peers.protect(lookup.PeerID)
+			trackLookup(ctx, u.host, u.sink, logEntry, probe, lookup)
 		case <-tCtx.Done():
 			return nil
 		}
@@ -150,81 +161,28 @@ func (u *UploadProbe) generateContent(ctx context.Context) (*blocks.BasicBlock,
 	return blocks.NewBlockWithCid(data, cid.NewCidV1(uint64(multicodec.Raw), ipfsutils.Hash(data)))
 }
 
-func (u *UploadProbe) trackProvider(ctx context.Context, provider peer.AddrInfo) error {
-	u.trackCount += 1
-	stats.Record(ctx, metrics.TrackCount.M(u.trackCount))
+func (u *UploadProbe) trackProvider(ctx context.Context, probe *sink.Probe, provider peer.AddrInfo) error {
+	stats.Record(ctx, metrics.TrackCount.M(atomic.AddInt64(&u.trackCount, 1)))
 
-	// Ensure provider is in peerstore
+	// Connect to the provider, which also runs the identify exchange, so that its agent version and protocols are known
 	err := u.host.Connect(ctx, provider)
 	if err != nil {
 		u.logEntry().WithError(err).WithField("peer", provider.ID).Infof("Error connecting to provider")
 	}
-
-	ps := u.host.Peerstore()
-
-	protocols, err := ps.GetProtocols(provider.ID)
-	if err != nil {
-		protocols = nil
-	}
-
-	var agentVersion string
-	if val, err := ps.Get(provider.ID, "AgentVersion"); err == nil {
-		agentVersion = val.(string)
-	}
-
-	maddrSet := map[string]ma.Multiaddr{}
-	for _, maddr := range ps.Addrs(provider.ID) {
-		maddrSet[maddr.String()] = maddr
-	}
-	for _, conn := range u.host.Network().ConnsToPeer(provider.ID) {
-		maddr := conn.RemoteMultiaddr()
-		maddrSet[maddr.String()] = maddr
-	}
-
-	maddrStrs := []string{}
-
-	ipAddressesSet := goset.NewSet[string]()
-	countriesSet := goset.NewSet[string]()
-	continentsSet := goset.NewSet[string]()
-	asnsSet := goset.NewSet[int64]()
-
-	for maddrStr, maddr := range maddrSet {
-		if utils.IsRelayedMaddr(maddr) || !manet.IsPublicAddr(maddr) {
-			continue
-		}
-
-		maddrStrs = append(maddrStrs, maddrStr)
-		maddrInfos, err := u.mmc.MaddrInfo(ctx, maddr)
-		if err != nil {
-			continue
-		}
-
-		for ipAddress, maddrInfo := range maddrInfos {
-			ipAddressesSet.Add(ipAddress)
-			countriesSet.Add(maddrInfo.Country)
-			continentsSet.Add(maddrInfo.Continent)
-			asnsSet.Add(int64(maddrInfo.ASN))
-		}
+	identified := u.identifier.Wait(ctx, provider.ID, IdentifyTimeout)
+	rtt := pingPeer(ctx, u.host, provider.ID)
+
+	sighting := newSighting(ctx, u.host, u.mmc, probe, provider.ID)
+	sighting.Identified = identified
+	sighting.PingRTT = rtt
+	if err = u.sink.TrackSighting(ctx, sighting); err != nil {
+		return errors.Wrap(err, "track sighting")
 	}
 
-	ipAddressesSet.Discard("")
-	countriesSet.Discard("")
-	continentsSet.Discard("")
-	asnsSet.Discard(0)
-
-	ipAddresses := ipAddressesSet.Items()
-	countries := countriesSet.Items()
-	continents := continentsSet.Items()
-	asns := asnsSet.Items()
-
-	sort.Strings(ipAddresses)
-	sort.Strings(countries)
-	sort.Strings(continents)
-	sort.Slice(asns, func(i, j int) bool { return asns[i] < asns[j] })
-
-	return insertModel(ctx, u.config.Database.DryRun, u.dbc, u.logEntry(), protocols, agentVersion, provider.ID,
-		ipAddresses, maddrStrs, countries, continents, asns, u.target.Type(), u.target.Name())
+	// Providers are only seen once, so there is nothing left to record.
+	finishSighting(u.sink, u.logEntry(), sighting, nil)
 
+	return nil
 }
 
 func (u *UploadProbe) logEntry() *log.Entry {
@@ -0,0 +1,256 @@
+package start
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kat-co/vala"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/dennis-tra/antares/pkg/config"
+)
+
+// ValidateConfig checks the given configuration for problems that prevent Antares from starting or targets
+// from being probed and returns a description of each of them. Each target is constructed like on startup,
+// so referenced secrets are resolved and the format of the credentials is checked as well.
+func ValidateConfig(conf *config.Config) []string {
+	val := vala.BeginValidation().Validate(isPort(conf.Prometheus.Port, "Prometheus.Port"))
+
+	// The listen addresses are derived from the host and port if none are configured
+	if len(conf.ListenAddrs) == 0 {
+		val = val.Validate(isIP(conf.Host, "Host"), isPort(conf.Port, "Port"))
+	}
+	for i, addr := range conf.ListenAddrs {
+		val = val.Validate(isMultiaddr(addr, fmt.Sprintf("ListenAddrs[%d]", i)))
+	}
+
+	val = val.Validate(
+		hasBootstrapPeers(conf.Network, "Network.BootstrapPeers"),
+		isSwarmKey(conf.Network, "Network.SwarmKeyPath"),
+		isProtocolPrefix(conf.Network.ProtocolPrefix, "Network.ProtocolPrefix"),
+		isNotNegative(conf.ResourceManager.MaxMemory, "ResourceManager.MaxMemory"),
+		isNotNegative(conf.ResourceManager.MaxFileDescriptors, "ResourceManager.MaxFileDescriptors"),
+		isWatermarks(conf.ConnectionManager, "ConnectionManager"),
+		isNotNegative(conf.ConnectionManager.GracePeriod, "ConnectionManager.GracePeriod"),
+		isNotNegative(conf.MaxConcurrentProbes, "MaxConcurrentProbes"),
+	)
+
+	names := map[string]string{}
+	for i, gw := range conf.Gateways {
+		param := fmt.Sprintf("Gateways[%d]", i)
+		val = val.Validate(
+			vala.StringNotEmpty(gw.Name, param+".Name"),
+			isGatewayURL(gw.URL, param+".URL"),
+		)
+		val = val.Validate(probeCheckers(gw.Probe, param)...)
+		val = val.Validate(
+			isConstructible(func() (Target, error) { return NewGatewayTarget(http.DefaultClient, gw, "") }, param, names),
+		)
+	}
+
+	names = map[string]string{}
+	for i, ps := range conf.PinningServices {
+		param := fmt.Sprintf("PinningServices[%d]", i)
+		val = val.Validate(probeCheckers(ps.Probe, param)...)
+
+		tc, found := PinningServiceTargetConstructors[ps.Target]
+		if !found {
+			val = val.Validate(isKnownTarget(ps.Target, PinningServiceTargetConstructors, param+".Target"))
+			continue
+		}
+
+		val = val.Validate(isConstructible(func() (Target, error) {
+			var err error
+			if ps.Authorization, err = config.ResolveSecret(ps.Authorization); err != nil {
+				return nil, err
+			}
+			return tc(nil, http.DefaultClient, ps)
+		}, param, names))
+	}
+
+	names = map[string]string{}
+	for i, us := range conf.UploadServices {
+		param := fmt.Sprintf("UploadServices[%d]", i)
+		val = val.Validate(probeCheckers(us.Probe, param)...)
+
+		tc, found := UploadServiceTargetConstructors[us.Target]
+		if !found {
+			val = val.Validate(isKnownTarget(us.Target, UploadServiceTargetConstructors, param+".Target"))
+			continue
+		}
+
+		val = val.Validate(isConstructible(func() (Target, error) {
+			var err error
+			if us.Authorization, err = config.ResolveSecret(us.Authorization); err != nil {
+				return nil, err
+			}
+			return tc(nil, http.DefaultClient, us)
+		}, param, names))
+	}
+
+	if val == nil {
+		return nil
+	}
+
+	return val.Errors
+}
+
+// isIP checks that the given host is an IP address that Antares can listen on.
+func isIP(host string, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return net.ParseIP(host) != nil, fmt.Sprintf("%s: %q is not an IP address", paramName, host)
+	}
+}
+
+// isMultiaddr checks that the given address is a valid multi address.
+func isMultiaddr(addr string, paramName string) vala.Checker {
+	return func() (bool, string) {
+		if _, err := ma.NewMultiaddr(addr); err != nil {
+			return false, fmt.Sprintf("%s: %s", paramName, err)
+		}
+		return true, ""
+	}
+}
+
+// isPort checks that the given port is a valid TCP or UDP port. Zero picks a random port.
+func isPort(port int, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return port >= 0 && port <= 65535, fmt.Sprintf("%s: %d is not a valid port", paramName, port)
+	}
+}
+
+// hasBootstrapPeers checks that the bootstrap peers are valid and that there are at least as many
+// as Antares must connect to.
+func hasBootstrapPeers(conf config.Network, paramName string) vala.Checker {
+	return func() (bool, string) {
+		peers, err := conf.BootstrapAddrInfos()
+		if err != nil {
+			return false, fmt.Sprintf("%s: %s", paramName, err)
+		}
+
+		if conf.MinBootstrapPeers < 0 || conf.MinBootstrapPeers > len(peers) {
+			return false, fmt.Sprintf("%s: at least %d bootstrap peers are required but %d are configured", paramName, conf.MinBootstrapPeers, len(peers))
+		}
+
+		return true, ""
+	}
+}
+
+// isSwarmKey checks that the swarm key of a private network can be read.
+func isSwarmKey(conf config.Network, paramName string) vala.Checker {
+	return func() (bool, string) {
+		if _, err := conf.SwarmKey(); err != nil {
+			return false, fmt.Sprintf("%s: %s", paramName, err)
+		}
+		return true, ""
+	}
+}
+
+// isProtocolPrefix checks that the given DHT protocol prefix is either empty or starts with a slash.
+func isProtocolPrefix(prefix string, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return prefix == "" || strings.HasPrefix(prefix, "/"), fmt.Sprintf("%s: %q doesn't start with a /", paramName, prefix)
+	}
+}
+
+// isNotNegative checks that the given value is zero or positive.
+func isNotNegative[T int | int64 | time.Duration](value T, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return value >= 0, fmt.Sprintf("%s: %v is negative", paramName, value)
+	}
+}
+
+// probeCheckers returns the checkers for the probe settings of the target with the given parameter name.
+// Zero values and omitted values fall back to the defaults of the target, so only negative values are invalid.
+func probeCheckers(conf config.Probe, paramName string) []vala.Checker {
+	checkers := []vala.Checker{
+		isProbeSetting(conf.Rate, paramName+".Rate"),
+		isProbeSetting(conf.Timeout, paramName+".Timeout"),
+		isProbeSetting(conf.Concurrency, paramName+".Concurrency"),
+		isProbeSetting(conf.Backoff.InitialInterval, paramName+".Backoff.InitialInterval"),
+		isProbeSetting(conf.Backoff.MaxInterval, paramName+".Backoff.MaxInterval"),
+		isProbeSetting(conf.Backoff.Multiplier, paramName+".Backoff.Multiplier"),
+	}
+	if conf.Backoff.MaxElapsedTime != nil {
+		checkers = append(checkers, isProbeSetting(*conf.Backoff.MaxElapsedTime, paramName+".Backoff.MaxElapsedTime"))
+	}
+	if conf.Backoff.RandomizationFactor != nil {
+		checkers = append(checkers, isProbeSetting(*conf.Backoff.RandomizationFactor, paramName+".Backoff.RandomizationFactor"))
+	}
+	return checkers
+}
+
+// isProbeSetting checks that the given probe setting isn't negative.
+func isProbeSetting[T int | float64 | time.Duration](value T, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return value >= 0, fmt.Sprintf("%s: %v is negative, set it to zero or more or omit it to use the default of the target", paramName, value)
+	}
+}
+
+// isWatermarks checks that the connection manager trims connections down to a lower number than it starts at.
+func isWatermarks(conf config.ConnectionManager, paramName string) vala.Checker {
+	return func() (bool, string) {
+		return conf.LowWater >= 0 && conf.LowWater < conf.HighWater,
+			fmt.Sprintf("%s: low water %d must be at least 0 and below high water %d", paramName, conf.LowWater, conf.HighWater)
+	}
+}
+
+// isGatewayURL checks that the given URL format string is an HTTP(S) URL that contains the CID placeholder.
+func isGatewayURL(urlFmt string, paramName string) vala.Checker {
+	return func() (bool, string) {
+		if !strings.Contains(urlFmt, GatewayURLReplaceStr) {
+			return false, fmt.Sprintf("%s: %q doesn't contain the %s placeholder", paramName, urlFmt, GatewayURLReplaceStr)
+		}
+
+		u, err := url.Parse(strings.ReplaceAll(urlFmt, GatewayURLReplaceStr, "cid"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return false, fmt.Sprintf("%s: %q is not an absolute http or https URL", paramName, urlFmt)
+		}
+
+		return true, ""
+	}
+}
+
+// isKnownTarget checks that a constructor for the given target exists.
+func isKnownTarget[T any](target string, constructors map[string]T, paramName string) vala.Checker {
+	return func() (bool, string) {
+		if _, found := constructors[target]; found {
+			return true, ""
+		}
+
+		known := make([]string, 0, len(constructors))
+		for name := range constructors {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+
+		return false, fmt.Sprintf("%s: unknown target %q, expected one of %s", paramName, target, strings.Join(known, ", "))
+	}
+}
+
+// isConstructible checks that the target can be constructed, that it's probed at a positive rate and that its name
+// isn't already taken by another target of the same kind. The given names map tracks the names that were seen so far.
+func isConstructible(construct func() (Target, error), paramName string, names map[string]string) vala.Checker {
+	return func() (bool, string) {
+		t, err := construct()
+		if err != nil {
+			return false, fmt.Sprintf("%s: %s", paramName, err)
+		}
+
+		if t.Rate() <= 0 {
+			return false, fmt.Sprintf("%s: rate %s is not positive, set the Rate to the interval between two probes, e.g., 5m", paramName, t.Rate())
+		}
+
+		if other, found := names[t.Name()]; found {
+			return false, fmt.Sprintf("%s: name %q is already used by %s", paramName, t.Name(), other)
+		}
+		names[t.Name()] = paramName
+
+		return true, ""
+	}
+}
@@ -0,0 +1,158 @@
+package start
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dennis-tra/antares/pkg/config"
+	"github.com/dennis-tra/antares/pkg/utils"
+)
+
+func TestValidateConfig(t *testing.T) {
+	valid := config.DefaultConfig
+	valid.Gateways = []config.Gateway{
+		{Name: "ipfs.io", URL: "https://ipfs.io/ipfs/{cid}"},
+		{Name: "dweb.link", URL: "https://{cid}.ipfs.dweb.link", Mode: GatewayModeRaw},
+	}
+	valid.PinningServices = []config.PinningService{
+		{Target: PinataTargetName, Authorization: "jwt"},
+		{Target: InfuraTargetName, Authorization: "project,secret"},
+		{Target: KuboTargetName, Endpoint: "http://127.0.0.1:5001"},
+		{Target: KuboTargetName, Name: "cluster", Endpoint: "http://127.0.0.1:9095"},
+	}
+	valid.UploadServices = []config.UploadService{
+		{Target: Web3TargetName, Authorization: "token"},
+	}
+	assert.Empty(t, ValidateConfig(&valid))
+
+	valid.Host = "::1"
+	assert.Empty(t, ValidateConfig(&valid))
+
+	listen := config.DefaultConfig
+	listen.Host = "localhost"
+	listen.ListenAddrs = []string{"/ip6/::/udp/2002/quic-v1", "/ip6/::/udp/quic"}
+	problems := ValidateConfig(&listen)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], `ListenAddrs[1]: failed to parse multiaddr "/ip6/::/udp/quic"`)
+
+	network := config.DefaultConfig
+	network.Network = config.Network{
+		BootstrapPeers:    []string{"/ip4/127.0.0.1/tcp/4001/p2p/12D3KooWGRUVh2tf3TYDnEB8jrrD7ojwBxWmu3b1ZXBm9UAGjdzH"},
+		MinBootstrapPeers: 2,
+		SwarmKeyPath:      filepath.Join(t.TempDir(), "swarm.key"),
+		ProtocolPrefix:    "testnet",
+	}
+	problems = ValidateConfig(&network)
+	require.Len(t, problems, 3)
+	assert.Equal(t, "Network.BootstrapPeers: at least 2 bootstrap peers are required but 1 are configured", problems[0])
+	assert.Contains(t, problems[1], "Network.SwarmKeyPath: open swarm key")
+	assert.Equal(t, `Network.ProtocolPrefix: "testnet" doesn't start with a /`, problems[2])
+
+	resources := config.DefaultConfig
+	resources.ResourceManager.MaxMemory = -1
+	resources.MaxConcurrentProbes = -1
+	resources.ConnectionManager = config.ConnectionManager{LowWater: 900, HighWater: 600, GracePeriod: -time.Second}
+	assert.Equal(t, []string{
+		"ResourceManager.MaxMemory: -1 is negative",
+		"ConnectionManager: low water 900 must be at least 0 and below high water 600",
+		"ConnectionManager.GracePeriod: -1s is negative",
+		"MaxConcurrentProbes: -1 is negative",
+	}, ValidateConfig(&resources))
+
+	probes := config.DefaultConfig
+	probes.Gateways = []config.Gateway{{
+		Name: "ipfs.io",
+		URL:  "https://ipfs.io/ipfs/{cid}",
+		Probe: config.Probe{
+			Rate:    -time.Minute,
+			Timeout: -time.Second,
+			Backoff: config.Backoff{
+				InitialInterval:     -time.Second,
+				MaxInterval:         -time.Minute,
+				MaxElapsedTime:      utils.Ptr(time.Duration(0)),
+				Multiplier:          -1.5,
+				RandomizationFactor: utils.Ptr(-0.5),
+			},
+		},
+	}}
+	probes.PinningServices = []config.PinningService{
+		{Target: KuboTargetName, Endpoint: "http://127.0.0.1:5001", Probe: config.Probe{Concurrency: -2}},
+	}
+	probes.UploadServices = []config.UploadService{
+		{Target: Web3TargetName, Authorization: "token", Probe: config.Probe{Backoff: config.Backoff{MaxElapsedTime: utils.Ptr(-time.Minute)}}},
+	}
+	assert.Equal(t, []string{
+		"Gateways[0].Rate: -1m0s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Timeout: -1s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Backoff.InitialInterval: -1s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Backoff.MaxInterval: -1m0s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Backoff.Multiplier: -1.5 is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Backoff.RandomizationFactor: -0.5 is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0]: rate -1m0s is not positive, set the Rate to the interval between two probes, e.g., 5m",
+		"PinningServices[0].Concurrency: -2 is negative, set it to zero or more or omit it to use the default of the target",
+		"UploadServices[0].Backoff.MaxElapsedTime: -1m0s is negative, set it to zero or more or omit it to use the default of the target",
+	}, ValidateConfig(&probes))
+
+	t.Setenv("TEST_INFURA_CREDENTIALS", "project")
+
+	invalid := config.DefaultConfig
+	invalid.Host = "localhost"
+	invalid.Port = 70000
+	invalid.Gateways = []config.Gateway{
+		{Name: "ipfs.io", URL: "https://ipfs.io/ipfs/"},
+		{Name: "ipfs.io", URL: "ipfs.io/ipfs/{cid}", Mode: "trustless", Probe: config.Probe{Concurrency: -1}},
+		{URL: "https://dweb.link/ipfs/{cid}"},
+	}
+	invalid.PinningServices = []config.PinningService{
+		{Target: "pinta", Authorization: "jwt"},
+		{Target: InfuraTargetName, Authorization: "env:TEST_INFURA_CREDENTIALS"},
+		{Target: KuboTargetName, Endpoint: "http://127.0.0.1:5001"},
+		{Target: KuboTargetName, Endpoint: "http://127.0.0.1:9095"},
+		{Target: PinataTargetName, Authorization: "env:TEST_MISSING_CREDENTIALS"},
+	}
+	invalid.UploadServices = []config.UploadService{
+		{Target: Web3TargetName},
+	}
+
+	assert.Equal(t, []string{
+		`Host: "localhost" is not an IP address`,
+		`Port: 70000 is not a valid port`,
+		`Gateways[0].URL: "https://ipfs.io/ipfs/" doesn't contain the {cid} placeholder`,
+		`Gateways[1].URL: "ipfs.io/ipfs/{cid}" is not an absolute http or https URL`,
+		`Gateways[1].Concurrency: -1 is negative, set it to zero or more or omit it to use the default of the target`,
+		`Gateways[1]: unknown gateway mode trustless`,
+		`Parameter is an empty string: Gateways[2].Name`,
+		`PinningServices[0].Target: unknown target "pinta", expected one of infura, kubo, pinata, psa`,
+		`PinningServices[1]: malformed infura credentials, expected project-id,api-key-secret`,
+		`PinningServices[3]: name "kubo" is already used by PinningServices[2]`,
+		`PinningServices[4]: environment variable TEST_MISSING_CREDENTIALS is not set`,
+		`UploadServices[0]: missing web3.storage api token`,
+	}, ValidateConfig(&invalid))
+}
+
+func TestValidateConfig_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+Gateways:
+  - Name: ipfs.io
+    URL: https://ipfs.io/ipfs/{cid}
+    Rate: -5m
+    Timeout: -1m
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	// Loading must not fail, so that all problems are listed
+	conf, err := config.Reload(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Gateways[0].Rate: -5m0s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0].Timeout: -1m0s is negative, set it to zero or more or omit it to use the default of the target",
+		"Gateways[0]: rate -5m0s is not positive, set the Rate to the interval between two probes, e.g., 5m",
+	}, ValidateConfig(conf))
+}
@@ -21,6 +21,60 @@ func IsRelayedMaddr(maddr ma.Multiaddr) bool {
 	}
 }
 
+// Transports of connections as they are determined by MaddrTransport.
+const (
+	TransportTCP          = "tcp"
+	TransportQUIC         = "quic"
+	TransportQUICV1       = "quic-v1"
+	TransportWebTransport = "webtransport"
+	TransportWebSocket    = "websocket"
+)
+
+// MaddrTransport returns the transport that's used to connect to the given multi address. It
+// returns an empty string for multi addresses of unknown transports.
+func MaddrTransport(maddr ma.Multiaddr) string {
+	transport := ""
+	for _, p := range maddr.Protocols() {
+		switch p.Code {
+		case ma.P_TCP:
+			transport = TransportTCP
+		case ma.P_QUIC:
+			transport = TransportQUIC
+		case ma.P_QUIC_V1:
+			transport = TransportQUICV1
+		case ma.P_WEBTRANSPORT:
+			return TransportWebTransport
+		case ma.P_WS, ma.P_WSS:
+			return TransportWebSocket
+		}
+	}
+	return transport
+}
+
+// IP families of multi addresses as they are determined by MaddrIPFamily.
+const (
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+)
+
+// MaddrIPFamily returns the IP family of the given multi address. It returns an empty
+// string if the multi address doesn't start with an IP address, e.g., a DNS name.
+func MaddrIPFamily(maddr ma.Multiaddr) string {
+	protocols := maddr.Protocols()
+	if len(protocols) == 0 {
+		return ""
+	}
+
+	switch protocols[0].Code {
+	case ma.P_IP4:
+		return IPFamilyIPv4
+	case ma.P_IP6:
+		return IPFamilyIPv6
+	default:
+		return ""
+	}
+}
+
 func IsContextErr(err error) bool {
 	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
@@ -28,3 +82,8 @@ func IsContextErr(err error) bool {
 func IsSuccessStatusCode(res *http.Response) bool {
 	return res.StatusCode >= 200 && res.StatusCode < 300
 }
+
+// Ptr returns a pointer to the given value, e.g., to set optional configuration values.
+func Ptr[T any](v T) *T {
+	return &v
+}
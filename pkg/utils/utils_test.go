@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaddrTransport(t *testing.T) {
+	tests := []struct {
+		maddr     string
+		transport string
+		ipFamily  string
+	}{
+		{maddr: "/ip4/1.2.3.4/tcp/4001", transport: TransportTCP, ipFamily: IPFamilyIPv4},
+		{maddr: "/ip6/::1/udp/4001/quic", transport: TransportQUIC, ipFamily: IPFamilyIPv6},
+		{maddr: "/ip6/::1/udp/4001/quic-v1", transport: TransportQUICV1, ipFamily: IPFamilyIPv6},
+		{maddr: "/ip4/1.2.3.4/udp/4001/quic-v1/webtransport", transport: TransportWebTransport, ipFamily: IPFamilyIPv4},
+		{maddr: "/ip4/1.2.3.4/tcp/4001/ws", transport: TransportWebSocket, ipFamily: IPFamilyIPv4},
+		{maddr: "/dns4/example.com/tcp/443/wss", transport: TransportWebSocket},
+		{maddr: "/ip4/1.2.3.4/udp/4001", transport: "", ipFamily: IPFamilyIPv4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.maddr, func(t *testing.T) {
+			maddr := ma.StringCast(tt.maddr)
+			assert.Equal(t, tt.transport, MaddrTransport(maddr))
+			assert.Equal(t, tt.ipFamily, MaddrIPFamily(maddr))
+		})
+	}
+}